@@ -0,0 +1,77 @@
+data "grafana_dashboard_json" "test" {
+  uid   = "test-ds-dashboard-json-uid"
+  title = "Service Overview"
+  tags  = ["generated"]
+
+  variable {
+    name  = "ds"
+    type  = "datasource"
+    query = "prometheus"
+  }
+
+  variable {
+    name  = "interval"
+    type  = "interval"
+    query = "1m,5m,1h"
+  }
+
+  panel {
+    type           = "stat"
+    title          = "Uptime"
+    datasource_uid = "$${ds}"
+    unit           = "percent"
+    min            = 0
+    max            = 100
+    width          = 6
+    height         = 4
+
+    target {
+      expr = "avg(up)"
+    }
+  }
+
+  panel {
+    type           = "timeseries"
+    title          = "Requests"
+    datasource_uid = "$${ds}"
+    width          = 18
+
+    target {
+      expr          = "sum(rate(http_requests_total[$interval]))"
+      legend_format = "requests"
+    }
+    target {
+      expr          = "sum(rate(http_errors_total[$interval]))"
+      legend_format = "errors"
+    }
+  }
+
+  row {
+    title = "Details"
+
+    panel {
+      type  = "table"
+      title = "Top endpoints"
+      width = 24
+    }
+  }
+
+  row {
+    title     = "Debug"
+    collapsed = true
+
+    panel {
+      type  = "logs"
+      title = "Logs"
+    }
+  }
+
+  link {
+    title = "Runbook"
+    url   = "https://example.com/runbook"
+  }
+}
+
+resource "grafana_dashboard" "test" {
+  config_json = data.grafana_dashboard_json.test.json
+}
@@ -0,0 +1,13 @@
+resource "grafana_dashboard_snapshot" "test" {
+  name = "Snapshots data source test"
+  config_json = jsonencode({
+    title  = "Frozen dashboard"
+    panels = []
+  })
+}
+
+data "grafana_dashboard_snapshots" "test" {
+  query = "Snapshots data source test"
+
+  depends_on = [grafana_dashboard_snapshot.test]
+}
@@ -0,0 +1,12 @@
+resource "grafana_dashboard" "test" {
+  message = "Updated the refresh interval"
+  config_json = jsonencode({
+    uid     = "test-ds-dashboard-versions-uid"
+    title   = "Production Overview"
+    refresh = "30s"
+  })
+}
+
+data "grafana_dashboard_versions" "test" {
+  dashboard_uid = grafana_dashboard.test.uid
+}
@@ -54,3 +54,19 @@ data "grafana_dashboards" "limit_one" {
     grafana_dashboard.data_source_dashboards2
   ]
 }
+
+// search by folder UID and title
+data "grafana_dashboards" "folder_uids_query" {
+  folder_uids = [grafana_folder.data_source_dashboards.uid]
+  query       = "data_source_dashboards"
+  depends_on  = [grafana_dashboard.data_source_dashboards1]
+}
+
+// search by dashboard UIDs, sorted
+data "grafana_dashboards" "dashboard_uids" {
+  dashboard_uids = [
+    grafana_dashboard.data_source_dashboards1.uid,
+    grafana_dashboard.data_source_dashboards2.uid,
+  ]
+  sort = "alpha-desc"
+}
@@ -6,3 +6,7 @@ resource "grafana_folder" "test" {
 data "grafana_folder" "from_title" {
   title = grafana_folder.test.title
 }
+
+data "grafana_folder" "from_path" {
+  path = grafana_folder.test.title // For nested folders: "Parent/Child/Grandchild"
+}
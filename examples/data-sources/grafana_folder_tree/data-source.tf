@@ -0,0 +1,19 @@
+resource "grafana_folder" "platform" {
+  title = "Platform"
+}
+
+resource "grafana_folder" "networking" {
+  title             = "Networking"
+  parent_folder_uid = grafana_folder.platform.uid
+}
+
+data "grafana_folder_tree" "all" {
+  depends_on = [grafana_folder.networking]
+}
+
+resource "grafana_dashboard" "edge" {
+  folder = data.grafana_folder_tree.all.uids_by_path["Platform/Networking"]
+  config_json = jsonencode({
+    title = "Edge"
+  })
+}
@@ -0,0 +1 @@
+terraform import grafana_basic_role_permissions.basic_role_permissions_name {{basic_role_uid}} # For example, basic_viewer
@@ -0,0 +1,14 @@
+resource "grafana_basic_role_permissions" "viewer" {
+  role = "Viewer"
+
+  # Viewers can't use Explore
+  removed_permissions {
+    action = "datasources:explore"
+  }
+
+  # Viewers can read the reports
+  added_permissions {
+    action = "reports:read"
+    scope  = "reports:*"
+  }
+}
@@ -0,0 +1,2 @@
+terraform import grafana_dashboard_permission_item.item_name {{dashboard_uid}}:{{user|team|role}}:{{user_id|team_id|role_name}} # To use the default provider org
+terraform import grafana_dashboard_permission_item.item_name {{org_id}}:{{dashboard_uid}}:{{user|team|role}}:{{user_id|team_id|role_name}} # When "org_id" is set on the resource
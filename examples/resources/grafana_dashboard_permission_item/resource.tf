@@ -0,0 +1,33 @@
+resource "grafana_team" "team" {
+  name = "Team Name"
+}
+
+resource "grafana_user" "user" {
+  email    = "user.name@example.com"
+  login    = "user.name"
+  password = "my-password"
+}
+
+resource "grafana_dashboard" "dashboard" {
+  config_json = jsonencode({
+    title = "My Dashboard"
+  })
+}
+
+resource "grafana_dashboard_permission_item" "on_role" {
+  dashboard_uid = grafana_dashboard.dashboard.uid
+  role          = "Viewer"
+  permission    = "Edit"
+}
+
+resource "grafana_dashboard_permission_item" "on_team" {
+  dashboard_uid = grafana_dashboard.dashboard.uid
+  team_id       = grafana_team.team.id
+  permission    = "View"
+}
+
+resource "grafana_dashboard_permission_item" "on_user" {
+  dashboard_uid = grafana_dashboard.dashboard.uid
+  user_id       = grafana_user.user.id
+  permission    = "Admin"
+}
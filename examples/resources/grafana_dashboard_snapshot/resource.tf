@@ -0,0 +1,27 @@
+resource "grafana_data_source" "test" {
+  type = "testdata"
+  name = "snapshot-testdata"
+}
+
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    uid   = "snapshot-incident-dashboard"
+    title = "Incident 1234"
+    panels = [{
+      id         = 1
+      type       = "timeseries"
+      title      = "Random walk"
+      gridPos    = { x = 0, y = 0, w = 12, h = 8 }
+      datasource = { uid = grafana_data_source.test.uid }
+      targets    = [{ refId = "A", scenarioId = "random_walk" }]
+    }]
+  })
+}
+
+resource "grafana_dashboard_snapshot" "test" {
+  dashboard_uid = grafana_dashboard.test.uid
+  name          = "Incident 1234 - stakeholders"
+  time_from     = "now-1h"
+  time_to       = "now"
+  expires_in    = 604800 // 1 week
+}
@@ -0,0 +1,15 @@
+resource "grafana_folder" "team" {
+  title = "Team Dashboards"
+}
+
+# dashboards/
+# ├── overview.json              -> saved in "Team Dashboards"
+# └── Platform/
+#     └── Networking/
+#         └── edge.json          -> saved in "Team Dashboards/Platform/Networking"
+resource "grafana_dashboards_directory" "team" {
+  path       = "${path.module}/dashboards"
+  folder_uid = grafana_folder.team.uid
+  prune      = true
+  message    = "Synced from git"
+}
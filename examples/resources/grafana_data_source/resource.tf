@@ -32,15 +32,12 @@ resource "grafana_data_source" "cloudwatch" {
   type = "cloudwatch"
   name = "cw-example"
 
-  json_data_encoded = jsonencode({
-    defaultRegion = "us-east-1"
-    authType      = "keys"
-  })
-
-  secure_json_data_encoded = jsonencode({
-    accessKey = "123"
-    secretKey = "456"
-  })
+  cloudwatch {
+    default_region = "us-east-1"
+    auth_type      = "keys"
+    access_key     = "123"
+    secret_key     = "456"
+  }
 }
 
 resource "grafana_data_source" "prometheus" {
@@ -50,10 +47,15 @@ resource "grafana_data_source" "prometheus" {
   basic_auth_enabled  = true
   basic_auth_username = "username"
 
+  prometheus {
+    http_method        = "POST"
+    prometheus_type    = "Mimir"
+    prometheus_version = "2.4.0"
+  }
+
+  // Keys that the typed block doesn't support can still be set in the encoded JSON data
   json_data_encoded = jsonencode({
-    httpMethod        = "POST"
-    prometheusType    = "Mimir"
-    prometheusVersion = "2.4.0"
+    exemplarTraceIdDestinations = [{ name = "traceID", datasourceUid = "tempo" }]
   })
 
   secure_json_data_encoded = jsonencode({
@@ -0,0 +1,2 @@
+terraform import grafana_data_source_correlation.correlation_name {{source_uid}}:{{correlation_uid}} # To use the default provider org
+terraform import grafana_data_source_correlation.correlation_name {{org_id}}:{{source_uid}}:{{correlation_uid}} # When "org_id" is set on the resource
@@ -0,0 +1,47 @@
+resource "grafana_data_source" "loki" {
+  type = "loki"
+  name = "loki"
+  url  = "http://loki:3100"
+}
+
+resource "grafana_data_source" "tempo" {
+  type = "tempo"
+  name = "tempo"
+  url  = "http://tempo:3200"
+}
+
+resource "grafana_data_source_correlation" "logs_to_traces" {
+  source_uid  = grafana_data_source.loki.uid
+  target_uid  = grafana_data_source.tempo.uid
+  label       = "Trace"
+  description = "Open the trace of the log line"
+
+  config {
+    field = "traceId"
+    target_json = jsonencode({
+      query = "$${traceId}"
+    })
+
+    transformation {
+      type       = "regex"
+      field      = "Line"
+      expression = "traceID=(\\w+)"
+      map_value  = "traceId"
+    }
+  }
+}
+
+resource "grafana_data_source_correlation" "logs_to_ci" {
+  source_uid = grafana_data_source.loki.uid
+  target_url = "https://ci.example.com/builds/$${build}"
+  label      = "CI build"
+
+  config {
+    type  = "external"
+    field = "build"
+
+    transformation {
+      type = "logfmt"
+    }
+  }
+}
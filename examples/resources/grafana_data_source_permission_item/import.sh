@@ -0,0 +1,2 @@
+terraform import grafana_data_source_permission_item.item_name {{datasource_uid}}:{{user|team|role}}:{{user_id|team_id|role_name}} # To use the default provider org
+terraform import grafana_data_source_permission_item.item_name {{org_id}}:{{datasource_uid}}:{{user|team|role}}:{{user_id|team_id|role_name}} # When "org_id" is set on the resource
@@ -0,0 +1,33 @@
+resource "grafana_team" "team" {
+  name = "Team Name"
+}
+
+resource "grafana_user" "user" {
+  email    = "user.name@example.com"
+  login    = "user.name"
+  password = "my-password"
+}
+
+resource "grafana_data_source" "foo" {
+  type = "prometheus"
+  name = "prometheus"
+  url  = "http://prometheus:9090"
+}
+
+resource "grafana_data_source_permission_item" "on_role" {
+  datasource_uid = grafana_data_source.foo.uid
+  role           = "Viewer"
+  permission     = "Query"
+}
+
+resource "grafana_data_source_permission_item" "on_team" {
+  datasource_uid = grafana_data_source.foo.uid
+  team_id        = grafana_team.team.id
+  permission     = "Edit"
+}
+
+resource "grafana_data_source_permission_item" "on_user" {
+  datasource_uid = grafana_data_source.foo.uid
+  user_id        = grafana_user.user.id
+  permission     = "Edit"
+}
@@ -0,0 +1,11 @@
+resource "grafana_folder" "test_folder" {
+  title = "Terraform Library Panel Folder UID Test"
+}
+
+resource "grafana_library_panel" "test_folder" {
+  name       = "test-folder-uid"
+  folder_uid = grafana_folder.test_folder.uid
+  model_json = jsonencode({
+    title = "test-folder-uid",
+  })
+}
@@ -0,0 +1 @@
+terraform import grafana_org_user.user_name {{org_id}}:{{user_id}}
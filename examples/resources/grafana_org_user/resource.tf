@@ -0,0 +1,18 @@
+resource "grafana_organization" "org" {
+  name = "Organization Name"
+
+  // Leave the users managed by grafana_org_user resources alone
+  ignore_externally_managed_users = true
+}
+
+resource "grafana_user" "user" {
+  email    = "user@example.com"
+  login    = "user"
+  password = "my-password"
+}
+
+resource "grafana_org_user" "user" {
+  org_id = grafana_organization.org.org_id
+  email  = grafana_user.user.email
+  role   = "Editor"
+}
@@ -0,0 +1,64 @@
+resource "grafana_folder" "rule_folder" {
+  title = "My Notification Settings Rule Folder"
+}
+
+resource "grafana_contact_point" "rule_contact_point" {
+  name = "My Rule Contact Point"
+
+  email {
+    addresses = ["one@company.org"]
+  }
+}
+
+resource "grafana_mute_timing" "rule_mute_timing" {
+  name = "My Rule Mute Timing"
+
+  intervals {
+    weekdays = ["saturday", "sunday"]
+  }
+}
+
+resource "grafana_rule_group" "my_alert_rule" {
+  name             = "My Rule Group"
+  folder_uid       = grafana_folder.rule_folder.uid
+  interval_seconds = 240
+  rule {
+    name      = "My Alert Rule 1"
+    for       = "2m"
+    condition = "B"
+    notification_settings {
+      contact_point   = grafana_contact_point.rule_contact_point.name
+      group_by        = ["alertname", "grafana_folder", "team"]
+      group_wait      = "45s"
+      group_interval  = "6m"
+      repeat_interval = "3h"
+      mute_timings    = [grafana_mute_timing.rule_mute_timing.name]
+    }
+    data {
+      ref_id = "A"
+      relative_time_range {
+        from = 600
+        to   = 0
+      }
+      datasource_uid = "PD8C576611E62080A"
+      model = jsonencode({
+        hide  = false
+        refId = "A"
+      })
+    }
+    data {
+      ref_id = "B"
+      relative_time_range {
+        from = 0
+        to   = 0
+      }
+      datasource_uid = "-100"
+      model = jsonencode({
+        expression = "A"
+        hide       = false
+        refId      = "B"
+        type       = "math"
+      })
+    }
+  }
+}
@@ -9,6 +9,17 @@ resource "grafana_service_account_token" "bar" {
   seconds_to_live    = 30
 }
 
+# Replaced by a new token a day before it expires
+resource "grafana_service_account_token" "rotating" {
+  name               = "key_rotating"
+  service_account_id = 1
+  seconds_to_live    = 2592000
+  rotate_before      = "24h"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
 
 output "service_account_token_foo_key_only" {
   value     = grafana_service_account_token.foo.key
@@ -0,0 +1 @@
+terraform import grafana_sso_settings.sso_settings_name {{provider_name}}
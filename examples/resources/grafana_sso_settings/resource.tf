@@ -0,0 +1,51 @@
+resource "grafana_sso_settings" "github" {
+  provider_name = "github"
+
+  oauth2_settings {
+    name                  = "GitHub"
+    client_id             = "github-client-id"
+    client_secret         = "github-client-secret"
+    enabled               = true
+    allow_sign_up         = true
+    scopes                = "user:email,read:org"
+    allowed_organizations = "my-org"
+    role_attribute_path   = "contains(groups[*], '@my-org/admins') && 'Admin' || 'Viewer'"
+  }
+}
+
+resource "grafana_sso_settings" "ldap" {
+  provider_name = "ldap"
+
+  ldap_settings {
+    enabled       = true
+    allow_sign_up = true
+
+    config {
+      servers {
+        host            = "ldap.example.com"
+        port            = 389
+        bind_dn         = "cn=admin,dc=example,dc=com"
+        bind_password   = "ldap-password"
+        search_filter   = "(cn=%s)"
+        search_base_dns = ["dc=example,dc=com"]
+
+        attributes = {
+          name      = "givenName"
+          surname   = "sn"
+          username  = "cn"
+          member_of = "memberOf"
+          email     = "email"
+        }
+
+        group_mappings {
+          group_dn = "cn=admins,ou=groups,dc=example,dc=com"
+          org_role = "Admin"
+        }
+        group_mappings {
+          group_dn = "*"
+          org_role = "Viewer"
+        }
+      }
+    }
+  }
+}
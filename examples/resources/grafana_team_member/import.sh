@@ -0,0 +1,2 @@
+terraform import grafana_team_member.member_name {{team_id}}:{{user_id}} # To use the default provider org
+terraform import grafana_team_member.member_name {{org_id}}:{{team_id}}:{{user_id}} # When the team is in another org
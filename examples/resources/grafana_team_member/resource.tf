@@ -0,0 +1,25 @@
+resource "grafana_user" "alice" {
+  email    = "alice@example.com"
+  login    = "alice"
+  password = "my-password"
+}
+
+resource "grafana_user" "bob" {
+  email    = "bob@example.com"
+  login    = "bob"
+  password = "my-password"
+}
+
+resource "grafana_team" "team" {
+  name    = "Team Name"
+  members = [grafana_user.alice.email]
+
+  // Leave the members managed by grafana_team_member resources alone
+  ignore_externally_managed_members = true
+}
+
+resource "grafana_team_member" "bob" {
+  team_id = grafana_team.team.id
+  login   = grafana_user.bob.login
+  admin   = true
+}
@@ -28,6 +28,9 @@ type Client struct {
 	OnCallClient *onCallAPI.Client
 
 	AlertingMutex sync.Mutex
+
+	// StoreDashboardSHA256 is the default for whether dashboards store only the sha256sum of their model JSON in the state.
+	StoreDashboardSHA256 bool
 }
 
 func (c *Client) GrafanaSubpath(path string) string {
@@ -1,8 +1,10 @@
 package common
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -38,3 +40,10 @@ func CheckReadError(resourceType string, d *schema.ResourceData, err error) (ret
 func IsNotFoundError(err error) bool {
 	return strings.Contains(err.Error(), NotFoundError)
 }
+
+// IsOAPINotFoundError returns true if the error is a 404 response of the OpenAPI client.
+// Its errors don't contain the same text as the ones of the legacy client, so IsNotFoundError doesn't match them.
+func IsOAPINotFoundError(err error) bool {
+	var codeErr interface{ IsCode(int) bool }
+	return errors.As(err, &codeErr) && codeErr.IsCode(http.StatusNotFound)
+}
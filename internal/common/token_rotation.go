@@ -0,0 +1,101 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// rotatedTokenNameRegexp matches the suffix added to the names of tokens that are rotated.
+var rotatedTokenNameRegexp = regexp.MustCompile(`^-\d+$`)
+
+// TokenRotationSchema returns the attributes that rotate a token: `rotate_before` and `rotation_trigger`.
+// lifetimeAttrs are the attributes that make the token expire, one of which is required by `rotate_before`.
+func TokenRotationSchema(lifetimeAttrs ...string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"rotate_before": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: ValidateDuration,
+			Description: fmt.Sprintf("Replace the token when it expires in less than this duration. For example, `24h`. Requires `%s`. "+
+				"Set `create_before_destroy` in the `lifecycle` block of the resource, so that the new token is created before the old one is deleted.",
+				strings.Join(lifetimeAttrs, "` or `")),
+		},
+		"rotation_trigger": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Description: "An arbitrary value that replaces the token when it changes. For example, the ID of a `time_rotating` resource. " +
+				"Set `create_before_destroy` in the `lifecycle` block of the resource, so that the new token is created before the old one is deleted.",
+		},
+	}
+}
+
+// TokenRotationCustomizeDiff plans the replacement of a token when it expires in less than `rotate_before`.
+// The replacement is planned on secretAttr, the computed attribute holding the token.
+// expiration returns the expiration of the token in the state, if it has one.
+func TokenRotationCustomizeDiff(secretAttr string, lifetimeAttrs []string, expiration func(d *schema.ResourceDiff) (time.Time, bool, error)) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		rotateBefore := d.Get("rotate_before").(string)
+		if rotateBefore == "" {
+			return nil
+		}
+
+		if rawConfig := d.GetRawConfig(); rawConfig.IsKnown() && !rawConfig.IsNull() {
+			hasLifetime := false
+			for _, attr := range lifetimeAttrs {
+				if v := rawConfig.GetAttr(attr); !v.IsKnown() || !v.IsNull() {
+					hasLifetime = true
+				}
+			}
+			if !hasLifetime {
+				return fmt.Errorf("rotate_before requires %s to be set", strings.Join(lifetimeAttrs, " or "))
+			}
+		}
+
+		if d.Id() == "" {
+			return nil
+		}
+		expiresAt, ok, err := expiration(d)
+		if err != nil || !ok {
+			return err
+		}
+		duration, err := time.ParseDuration(rotateBefore)
+		if err != nil {
+			return err
+		}
+		if time.Until(expiresAt) > duration {
+			return nil
+		}
+
+		log.Printf("[DEBUG] token %s expires at %s, which is in less than %s: replacing it", d.Id(), expiresAt, rotateBefore)
+		if err := d.SetNewComputed(secretAttr); err != nil {
+			return err
+		}
+		return d.ForceNew(secretAttr)
+	}
+}
+
+// RotatedTokenName returns the name of a token to create. Token names must be unique, so when the token can be rotated,
+// a suffix is added to the name, so that the new token can be created before the old one is deleted.
+func RotatedTokenName(d *schema.ResourceData) string {
+	name := d.Get("name").(string)
+	if d.Get("rotate_before").(string) == "" && d.Get("rotation_trigger").(string) == "" {
+		return name
+	}
+	return fmt.Sprintf("%s-%d", name, time.Now().Unix())
+}
+
+// TokenNameFromAPI returns the name of a token to set in the state, removing the suffix added by RotatedTokenName.
+func TokenNameFromAPI(d *schema.ResourceData, apiName string) string {
+	name := d.Get("name").(string)
+	if name != "" && strings.HasPrefix(apiName, name) && rotatedTokenNameRegexp.MatchString(strings.TrimPrefix(apiName, name)) {
+		return name
+	}
+	return apiName
+}
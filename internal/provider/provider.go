@@ -52,33 +52,43 @@ func Provider(version string) func() *schema.Provider {
 		// Resources that require the Grafana client to exist.
 		grafanaClientResources = addResourcesMetadataValidation(grafanaClientPresent, map[string]*schema.Resource{
 			// Grafana
-			"grafana_annotation":                 grafana.ResourceAnnotation(),
-			"grafana_api_key":                    grafana.ResourceAPIKey(),
-			"grafana_contact_point":              grafana.ResourceContactPoint(),
-			"grafana_dashboard":                  grafana.ResourceDashboard(),
-			"grafana_dashboard_public":           grafana.ResourcePublicDashboard(),
-			"grafana_dashboard_permission":       grafana.ResourceDashboardPermission(),
-			"grafana_data_source":                grafana.ResourceDataSource(),
-			"grafana_data_source_permission":     grafana.ResourceDatasourcePermission(),
-			"grafana_folder":                     grafana.ResourceFolder(),
-			"grafana_folder_permission":          grafana.ResourceFolderPermission(),
-			"grafana_library_panel":              grafana.ResourceLibraryPanel(),
-			"grafana_message_template":           grafana.ResourceMessageTemplate(),
-			"grafana_mute_timing":                grafana.ResourceMuteTiming(),
-			"grafana_notification_policy":        grafana.ResourceNotificationPolicy(),
-			"grafana_organization":               grafana.ResourceOrganization(),
-			"grafana_organization_preferences":   grafana.ResourceOrganizationPreferences(),
-			"grafana_playlist":                   grafana.ResourcePlaylist(),
-			"grafana_report":                     grafana.ResourceReport(),
-			"grafana_role":                       grafana.ResourceRole(),
-			"grafana_role_assignment":            grafana.ResourceRoleAssignment(),
-			"grafana_rule_group":                 grafana.ResourceRuleGroup(),
-			"grafana_team":                       grafana.ResourceTeam(),
-			"grafana_team_external_group":        grafana.ResourceTeamExternalGroup(),
-			"grafana_service_account_token":      grafana.ResourceServiceAccountToken(),
-			"grafana_service_account":            grafana.ResourceServiceAccount(),
-			"grafana_service_account_permission": grafana.ResourceServiceAccountPermission(),
-			"grafana_user":                       grafana.ResourceUser(),
+			"grafana_annotation":                  grafana.ResourceAnnotation(),
+			"grafana_api_key":                     grafana.ResourceAPIKey(),
+			"grafana_contact_point":               grafana.ResourceContactPoint(),
+			"grafana_dashboard":                   grafana.ResourceDashboard(),
+			"grafana_dashboard_public":            grafana.ResourcePublicDashboard(),
+			"grafana_dashboard_snapshot":          grafana.ResourceDashboardSnapshot(),
+			"grafana_dashboards_directory":        grafana.ResourceDashboardsDirectory(),
+			"grafana_dashboard_permission":        grafana.ResourceDashboardPermission(),
+			"grafana_dashboard_permission_item":   grafana.ResourceDashboardPermissionItem(),
+			"grafana_data_source":                 grafana.ResourceDataSource(),
+			"grafana_data_source_correlation":     grafana.ResourceDataSourceCorrelation(),
+			"grafana_data_source_permission":      grafana.ResourceDatasourcePermission(),
+			"grafana_data_source_permission_item": grafana.ResourceDatasourcePermissionItem(),
+			"grafana_folder":                      grafana.ResourceFolder(),
+			"grafana_folder_permission":           grafana.ResourceFolderPermission(),
+			"grafana_folder_permission_item":      grafana.ResourceFolderPermissionItem(),
+			"grafana_library_panel":               grafana.ResourceLibraryPanel(),
+			"grafana_message_template":            grafana.ResourceMessageTemplate(),
+			"grafana_mute_timing":                 grafana.ResourceMuteTiming(),
+			"grafana_notification_policy":         grafana.ResourceNotificationPolicy(),
+			"grafana_organization":                grafana.ResourceOrganization(),
+			"grafana_organization_preferences":    grafana.ResourceOrganizationPreferences(),
+			"grafana_org_user":                    grafana.ResourceOrgUser(),
+			"grafana_playlist":                    grafana.ResourcePlaylist(),
+			"grafana_report":                      grafana.ResourceReport(),
+			"grafana_role":                        grafana.ResourceRole(),
+			"grafana_role_assignment":             grafana.ResourceRoleAssignment(),
+			"grafana_basic_role_permissions":      grafana.ResourceBasicRolePermissions(),
+			"grafana_rule_group":                  grafana.ResourceRuleGroup(),
+			"grafana_team":                        grafana.ResourceTeam(),
+			"grafana_team_member":                 grafana.ResourceTeamMember(),
+			"grafana_team_external_group":         grafana.ResourceTeamExternalGroup(),
+			"grafana_service_account_token":       grafana.ResourceServiceAccountToken(),
+			"grafana_service_account":             grafana.ResourceServiceAccount(),
+			"grafana_service_account_permission":  grafana.ResourceServiceAccountPermission(),
+			"grafana_sso_settings":                grafana.ResourceSSOSettings(),
+			"grafana_user":                        grafana.ResourceUser(),
 
 			// Machine Learning
 			"grafana_machine_learning_job":              machinelearning.ResourceJob(),
@@ -122,9 +132,13 @@ func Provider(version string) func() *schema.Provider {
 		grafanaClientDatasources = addResourcesMetadataValidation(grafanaClientPresent, map[string]*schema.Resource{
 			"grafana_dashboard":                grafana.DatasourceDashboard(),
 			"grafana_dashboards":               grafana.DatasourceDashboards(),
+			"grafana_dashboard_versions":       grafana.DatasourceDashboardVersions(),
+			"grafana_dashboard_json":           grafana.DatasourceDashboardJSON(),
+			"grafana_dashboard_snapshots":      grafana.DatasourceDashboardSnapshots(),
 			"grafana_data_source":              grafana.DatasourceDatasource(),
 			"grafana_folder":                   grafana.DatasourceFolder(),
 			"grafana_folders":                  grafana.DatasourceFolders(),
+			"grafana_folder_tree":              grafana.DatasourceFolderTree(),
 			"grafana_library_panel":            grafana.DatasourceLibraryPanel(),
 			"grafana_user":                     grafana.DatasourceUser(),
 			"grafana_users":                    grafana.DatasourceUsers(),
@@ -272,7 +286,7 @@ func Provider(version string) func() *schema.Provider {
 					Type:        schema.TypeBool,
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("GRAFANA_STORE_DASHBOARD_SHA256", false),
-					Description: "Set to true if you want to save only the sha256sum instead of complete dashboard model JSON in the tfstate.",
+					Description: "Set to true if you want to save only the sha256sum instead of complete dashboard model JSON in the tfstate. Can be overridden on each dashboard with the `store_sha256` attribute.",
 				},
 
 				"oncall_access_token": {
@@ -369,7 +383,7 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 			c.OnCallClient = onCallClient
 		}
 
-		grafana.StoreDashboardSHA256 = d.Get("store_dashboard_sha256").(bool)
+		c.StoreDashboardSHA256 = d.Get("store_dashboard_sha256").(bool)
 
 		return c, diags
 	}
@@ -14,9 +14,12 @@ import (
 )
 
 func ResourceAccessPolicyToken() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 
 		Description: `
+Tokens with an expiration can be rotated automatically with ` + "`rotate_before`" + `. The expiration must then be set with ` + "`expires_in`" + `,
+and ` + "`create_before_destroy`" + ` must be set, so that the new token is available before the old one is deleted.
+
 * [Official documentation](https://grafana.com/docs/grafana-cloud/account-management/authentication-and-permissions/access-policies/)
 * [API documentation](https://grafana.com/docs/grafana-cloud/developer-resources/api-reference/cloud-api/#create-a-token)
 `,
@@ -25,6 +28,14 @@ func ResourceAccessPolicyToken() *schema.Resource {
 		UpdateContext: UpdateCloudAccessPolicyToken,
 		DeleteContext: DeleteCloudAccessPolicyToken,
 		ReadContext:   ReadCloudAccessPolicyToken,
+		CustomizeDiff: common.TokenRotationCustomizeDiff("token", []string{"expires_at", "expires_in"}, func(d *schema.ResourceDiff) (time.Time, bool, error) {
+			expiresAt := d.Get("expires_at").(string)
+			if expiresAt == "" {
+				return time.Time{}, false, nil
+			}
+			t, err := time.Parse(time.RFC3339, expiresAt)
+			return t, err == nil, err
+		}),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -61,11 +72,21 @@ func ResourceAccessPolicyToken() *schema.Resource {
 				},
 			},
 			"expires_at": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				Description:  "Expiration date of the access policy token. Does not expire by default.",
-				ValidateFunc: validation.IsRFC3339Time,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"expires_in"},
+				Description:   "Expiration date of the access policy token. Does not expire by default.",
+				ValidateFunc:  validation.IsRFC3339Time,
+			},
+			"expires_in": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"expires_at"},
+				ValidateDiagFunc: common.ValidateDuration,
+				Description:      "Lifetime of the access policy token, from its creation. For example, `720h`. The expiration date is set in `expires_at`.",
 			},
 
 			// Computed
@@ -86,16 +107,24 @@ func ResourceAccessPolicyToken() *schema.Resource {
 			},
 		},
 	}
+	for k, v := range common.TokenRotationSchema("expires_at", "expires_in") {
+		r.Schema[k] = v
+	}
+	return r
 }
 
 func CreateCloudAccessPolicyToken(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*common.Client).GrafanaCloudAPI
 	region := d.Get("region").(string)
 
+	displayName := d.Get("display_name").(string)
+	if displayName == "" {
+		displayName = d.Get("name").(string)
+	}
 	tokenInput := gapi.CreateCloudAccessPolicyTokenInput{
 		AccessPolicyID: d.Get("access_policy_id").(string),
-		Name:           d.Get("name").(string),
-		DisplayName:    d.Get("display_name").(string),
+		Name:           common.RotatedTokenName(d),
+		DisplayName:    displayName,
 	}
 
 	if v, ok := d.GetOk("expires_at"); ok {
@@ -104,6 +133,13 @@ func CreateCloudAccessPolicyToken(ctx context.Context, d *schema.ResourceData, m
 			return diag.FromErr(err)
 		}
 		tokenInput.ExpiresAt = &expiresAt
+	} else if v, ok := d.GetOk("expires_in"); ok {
+		expiresIn, err := time.ParseDuration(v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		expiresAt := time.Now().Add(expiresIn).UTC().Truncate(time.Second)
+		tokenInput.ExpiresAt = &expiresAt
 	}
 
 	result, err := client.CreateCloudAccessPolicyToken(region, tokenInput)
@@ -148,7 +184,7 @@ func ReadCloudAccessPolicyToken(ctx context.Context, d *schema.ResourceData, met
 
 	d.Set("access_policy_id", result.AccessPolicyID)
 	d.Set("region", region)
-	d.Set("name", result.Name)
+	d.Set("name", common.TokenNameFromAPI(d, result.Name))
 	d.Set("display_name", result.DisplayName)
 	d.Set("created_at", result.CreatedAt.Format(time.RFC3339))
 	if result.ExpiresAt != nil {
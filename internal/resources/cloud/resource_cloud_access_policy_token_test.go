@@ -142,6 +142,56 @@ func TestResourceAccessPolicyToken_NoExpiration(t *testing.T) {
 	})
 }
 
+func TestResourceAccessPolicyToken_Rotation(t *testing.T) {
+	t.Parallel()
+	testutils.CheckCloudAPITestsEnabled(t)
+
+	var policy gapi.CloudAccessPolicy
+	var policyToken, rotatedPolicyToken gapi.CloudAccessPolicyToken
+
+	// The token expires in less than `rotate_before`, so it's replaced on every plan
+	config := strings.Replace(
+		testAccCloudAccessPolicyTokenConfigBasic("initial-rotation", "", []string{"metrics:read"}, ""),
+		`name             = "token-initial-rotation"`,
+		`name             = "token-initial-rotation"
+		expires_in       = "1h"
+		rotate_before    = "2h"
+		lifecycle {
+			create_before_destroy = true
+		}`, 1)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCloudAccessPolicyCheckExists("grafana_cloud_access_policy.test", &policy),
+					testAccCloudAccessPolicyTokenCheckExists("grafana_cloud_access_policy_token.test", &policyToken),
+					resource.TestCheckResourceAttr("grafana_cloud_access_policy_token.test", "name", "token-initial-rotation"),
+					resource.TestCheckResourceAttr("grafana_cloud_access_policy_token.test", "display_name", "token-initial-rotation"),
+					resource.TestCheckResourceAttrSet("grafana_cloud_access_policy_token.test", "expires_at"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCloudAccessPolicyTokenCheckExists("grafana_cloud_access_policy_token.test", &rotatedPolicyToken),
+					testAccCloudAccessPolicyTokenCheckDestroy("us", &policyToken),
+					func(s *terraform.State) error {
+						if rotatedPolicyToken.ID == policyToken.ID {
+							return fmt.Errorf("expected the token to be rotated")
+						}
+						return nil
+					},
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCloudAccessPolicyCheckExists(rn string, a *gapi.CloudAccessPolicy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -3,11 +3,13 @@ package grafana_test
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/access_control"
 	"github.com/grafana/grafana-openapi-client-go/client/annotations"
+	"github.com/grafana/grafana-openapi-client-go/client/correlations"
 	"github.com/grafana/grafana-openapi-client-go/client/datasources"
 	"github.com/grafana/grafana-openapi-client-go/client/folders"
 	"github.com/grafana/grafana-openapi-client-go/client/library_elements"
@@ -34,6 +36,15 @@ var (
 			return payloadOrError(resp, err)
 		},
 	)
+	correlationCheckExists = newCheckExistsHelper(
+		func(c *models.Correlation) string { return c.SourceUID + ":" + c.UID },
+		func(client *goapi.GrafanaHTTPAPI, id string) (*models.Correlation, error) {
+			sourceUID, uid, _ := strings.Cut(id, ":")
+			params := correlations.NewGetCorrelationParams().WithSourceUID(sourceUID).WithCorrelationUID(uid)
+			resp, err := client.Correlations.GetCorrelation(params, nil)
+			return payloadOrError(resp, err)
+		},
+	)
 	datasourceCheckExists = newCheckExistsHelper(
 		func(d *models.DataSource) string { return strconv.FormatInt(d.ID, 10) },
 		func(client *goapi.GrafanaHTTPAPI, id string) (*models.DataSource, error) {
@@ -0,0 +1,207 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// diffDashboardModels returns a human-readable list of the changes between two dashboard models.
+// Panels are matched by title (and variables by name) rather than by position, so that moving
+// a panel or adding one at the top doesn't show every other panel as modified.
+func diffDashboardModels(oldModel, newModel map[string]interface{}) []string {
+	var changes []string
+
+	oldPanels, newPanels := dashboardPanelsByKey(oldModel), dashboardPanelsByKey(newModel)
+	changes = append(changes, diffKeyedItems("panel", oldPanels, newPanels)...)
+
+	oldVariables, newVariables := dashboardVariablesByName(oldModel), dashboardVariablesByName(newModel)
+	changes = append(changes, diffKeyedItems("variable", oldVariables, newVariables)...)
+
+	// Other top-level attributes are compared as a whole
+	ignored := map[string]bool{"panels": true, "templating": true, "id": true, "version": true}
+	keys := map[string]bool{}
+	for k := range oldModel {
+		keys[k] = true
+	}
+	for k := range newModel {
+		keys[k] = true
+	}
+	var attributeChanges []string
+	for k := range keys {
+		if ignored[k] {
+			continue
+		}
+		oldValue, oldOk := oldModel[k]
+		newValue, newOk := newModel[k]
+		switch {
+		case !oldOk:
+			attributeChanges = append(attributeChanges, fmt.Sprintf("+ %s: %s", k, compactJSON(newValue)))
+		case !newOk:
+			attributeChanges = append(attributeChanges, fmt.Sprintf("- %s", k))
+		case !reflect.DeepEqual(oldValue, newValue):
+			attributeChanges = append(attributeChanges, fmt.Sprintf("~ %s: %s -> %s", k, compactJSON(oldValue), compactJSON(newValue)))
+		}
+	}
+	sort.Strings(attributeChanges)
+
+	return append(changes, attributeChanges...)
+}
+
+// diffKeyedItems compares two sets of dashboard items (panels, variables) and reports which were added, removed or modified.
+// For modified items, the names of the changed attributes are listed.
+func diffKeyedItems(kind string, oldItems, newItems map[string]map[string]interface{}) []string {
+	var added, removed, modified []string
+	for key, newItem := range newItems {
+		oldItem, ok := oldItems[key]
+		if !ok {
+			added = append(added, fmt.Sprintf("+ %s %q", kind, key))
+			continue
+		}
+		if attrs := changedAttributes(oldItem, newItem); len(attrs) > 0 {
+			for i, attr := range attrs {
+				if attr == "targets" {
+					attrs[i] = describeTargetChanges(oldItem[attr], newItem[attr])
+				}
+			}
+			modified = append(modified, fmt.Sprintf("~ %s %q: %s", kind, key, strings.Join(attrs, ", ")))
+		}
+	}
+	for key := range oldItems {
+		if _, ok := newItems[key]; !ok {
+			removed = append(removed, fmt.Sprintf("- %s %q", kind, key))
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(modified)
+	return append(append(added, removed...), modified...)
+}
+
+// describeTargetChanges describes the changes to the queries of a panel, matched by their refId.
+func describeTargetChanges(oldTargets, newTargets interface{}) string {
+	byRefID := func(targets interface{}) map[string]interface{} {
+		result := map[string]interface{}{}
+		list, _ := targets.([]interface{})
+		for i, t := range list {
+			target, _ := t.(map[string]interface{})
+			refID, _ := target["refId"].(string)
+			if refID == "" {
+				refID = fmt.Sprintf("#%d", i)
+			}
+			result[refID] = t
+		}
+		return result
+	}
+	oldByRefID, newByRefID := byRefID(oldTargets), byRefID(newTargets)
+
+	var changes []string
+	for refID, target := range newByRefID {
+		oldTarget, ok := oldByRefID[refID]
+		switch {
+		case !ok:
+			changes = append(changes, refID+" added")
+		case !reflect.DeepEqual(oldTarget, target):
+			changes = append(changes, refID+" modified")
+		}
+	}
+	for refID := range oldByRefID {
+		if _, ok := newByRefID[refID]; !ok {
+			changes = append(changes, refID+" removed")
+		}
+	}
+	if len(changes) == 0 {
+		return "targets (reordered)"
+	}
+	sort.Strings(changes)
+	return fmt.Sprintf("targets (%s)", strings.Join(changes, ", "))
+}
+
+// changedAttributes returns the sorted names of the attributes that differ between two JSON objects.
+func changedAttributes(oldItem, newItem map[string]interface{}) []string {
+	var attrs []string
+	for k, v := range newItem {
+		if !reflect.DeepEqual(oldItem[k], v) {
+			attrs = append(attrs, k)
+		}
+	}
+	for k := range oldItem {
+		if _, ok := newItem[k]; !ok {
+			attrs = append(attrs, k)
+		}
+	}
+	sort.Strings(attrs)
+	return attrs
+}
+
+// dashboardPanelsByKey indexes the panels of a dashboard model, including the ones within collapsed rows.
+// Panels are keyed by title, falling back to their id (or position) for untitled panels.
+// Duplicate titles are disambiguated with a counter.
+func dashboardPanelsByKey(model map[string]interface{}) map[string]map[string]interface{} {
+	result := map[string]map[string]interface{}{}
+	var index func(panels []interface{})
+	index = func(panels []interface{}) {
+		for i, p := range panels {
+			panel, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key, _ := panel["title"].(string)
+			if key == "" {
+				if id, ok := panel["id"].(float64); ok {
+					key = fmt.Sprintf("#%d", int64(id))
+				} else {
+					key = fmt.Sprintf("#%d", i)
+				}
+			}
+			uniqueKey := key
+			for n := 2; result[uniqueKey] != nil; n++ {
+				uniqueKey = fmt.Sprintf("%s (%d)", key, n)
+			}
+			item := map[string]interface{}{}
+			for k, v := range panel {
+				if k == "panels" {
+					continue
+				}
+				item[k] = v
+			}
+			result[uniqueKey] = item
+			if nested, ok := panel["panels"].([]interface{}); ok {
+				index(nested)
+			}
+		}
+	}
+	if panels, ok := model["panels"].([]interface{}); ok {
+		index(panels)
+	}
+	return result
+}
+
+// dashboardVariablesByName indexes the template variables of a dashboard model by name.
+func dashboardVariablesByName(model map[string]interface{}) map[string]map[string]interface{} {
+	result := map[string]map[string]interface{}{}
+	templating, ok := model["templating"].(map[string]interface{})
+	if !ok {
+		return result
+	}
+	variables, _ := templating["list"].([]interface{})
+	for i, v := range variables {
+		variable, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := variable["name"].(string)
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		result[name] = variable
+	}
+	return result
+}
+
+func compactJSON(v interface{}) string {
+	j, _ := json.Marshal(v)
+	return string(j)
+}
@@ -0,0 +1,292 @@
+package grafana
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
+)
+
+const (
+	dashboardValidationOff   = "off"
+	dashboardValidationWarn  = "warn"
+	dashboardValidationError = "error"
+
+	// dashboardLatestSchemaVersion is the latest dashboard schema version known to the provider.
+	// Newer versions are only warned about, since they come from newer versions of Grafana.
+	dashboardLatestSchemaVersion = 41
+)
+
+//go:embed dashboard_schema.json
+var dashboardSchemaJSON []byte
+
+var dashboardSchema = func() *spec.Schema {
+	var schema spec.Schema
+	if err := json.Unmarshal(dashboardSchemaJSON, &schema); err != nil {
+		panic(fmt.Sprintf("invalid embedded dashboard schema: %s", err))
+	}
+	if err := spec.ExpandSchema(&schema, &schema, nil); err != nil {
+		panic(fmt.Sprintf("invalid embedded dashboard schema: %s", err))
+	}
+	return &schema
+}()
+
+// builtinPanelTypes are the panel types shipped with Grafana.
+// They are used to validate panel types when the installed panel plugins can't be listed.
+var builtinPanelTypes = []string{
+	"alertlist", "annolist", "barchart", "bargauge", "candlestick", "canvas", "dashlist", "datagrid", "debug",
+	"flamegraph", "gauge", "geomap", "gettingstarted", "graph", "heatmap", "histogram", "live", "logs", "news",
+	"nodeGraph", "piechart", "row", "singlestat", "stat", "state-timeline", "status-history", "table", "table-old",
+	"text", "timeseries", "traces", "trend", "welcome", "xychart",
+}
+
+// builtinDatasourceUIDs are the special data sources that aren't listed by the data sources API.
+var builtinDatasourceUIDs = map[string]bool{
+	"grafana": true, "-- Grafana --": true,
+	"-- Mixed --": true, "mixed": true,
+	"-- Dashboard --": true, "dashboard": true,
+}
+
+var dashboardVariableRefRegexp = regexp.MustCompile(`^\$(?:\{([^}:]+)(?::[^}]*)?\}|(\w+))$`)
+
+// dashboardValidationContext holds what is known about the Grafana instance to validate dashboards against.
+type dashboardValidationContext struct {
+	panelTypes map[string]bool
+	// datasources holds the UIDs and names of the data sources. Nil if they couldn't be listed.
+	datasources map[string]bool
+}
+
+// newDashboardValidationContext lists the panel plugins and data sources of the instance.
+// If the client is nil, only the built-in panel types are known and data sources aren't checked.
+func newDashboardValidationContext(client *goapi.GrafanaHTTPAPI) (*dashboardValidationContext, error) {
+	vc := &dashboardValidationContext{panelTypes: map[string]bool{}}
+	for _, panelType := range builtinPanelTypes {
+		vc.panelTypes[panelType] = true
+	}
+	if client == nil {
+		return vc, nil
+	}
+
+	var plugins []struct {
+		ID string `json:"id"`
+	}
+	if err := oapiRequest(client, "GET", "/plugins", url.Values{"type": []string{"panel"}}, nil, &plugins); err != nil {
+		return nil, fmt.Errorf("failed to list panel plugins: %w", err)
+	}
+	for _, plugin := range plugins {
+		vc.panelTypes[plugin.ID] = true
+	}
+
+	resp, err := client.Datasources.GetDataSources(datasources.NewGetDataSourcesParams(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data sources: %w", err)
+	}
+	vc.datasources = map[string]bool{}
+	for _, ds := range resp.Payload {
+		vc.datasources[ds.UID] = true
+		vc.datasources[ds.Name] = true
+	}
+
+	return vc, nil
+}
+
+// validateDashboardModel checks a dashboard model against the embedded dashboard schema,
+// then checks the panels' ids, positions and types, and the data sources it references.
+// It returns the list of issues found, and warnings that aren't issues, such as a schema version newer than the latest known one.
+func validateDashboardModel(model map[string]interface{}, vc *dashboardValidationContext) (issues []string, warnings []string) {
+	if version, ok := model["schemaVersion"].(float64); ok && version > dashboardLatestSchemaVersion {
+		warnings = append(warnings, fmt.Sprintf("schemaVersion %v is newer than the latest version known to the provider (%d)", version, dashboardLatestSchemaVersion))
+	}
+
+	if err := validate.AgainstSchema(dashboardSchema, model, strfmt.Default); err != nil {
+		if composite, ok := err.(*errors.CompositeError); ok {
+			for _, e := range composite.Errors {
+				// allOf failures are redundant with the errors of the sub-schemas
+				if !strings.Contains(e.Error(), "must validate all the schemas") {
+					issues = append(issues, e.Error())
+				}
+			}
+		} else {
+			issues = append(issues, err.Error())
+		}
+	}
+
+	panels, _ := model["panels"].([]interface{})
+	issues = append(issues, validateDashboardPanelIDs(panels)...)
+	issues = append(issues, validateDashboardPanelPositions("", panels)...)
+	for _, panel := range allDashboardPanels(panels) {
+		if panelType, ok := panel["type"].(string); ok && panelType != "" && !vc.panelTypes[panelType] {
+			issues = append(issues, fmt.Sprintf("panel %s has an unknown type: %s", describePanel(panel), panelType))
+		}
+	}
+
+	issues = append(issues, validateDashboardDatasources(model, vc)...)
+
+	return issues, warnings
+}
+
+func validateDashboardPanelIDs(panels []interface{}) []string {
+	var issues []string
+	seen := map[float64]string{}
+	for _, panel := range allDashboardPanels(panels) {
+		id, ok := panel["id"].(float64)
+		if !ok {
+			continue
+		}
+		if other, ok := seen[id]; ok {
+			issues = append(issues, fmt.Sprintf("panels %s and %s have the same id: %v", other, describePanel(panel), id))
+			continue
+		}
+		seen[id] = describePanel(panel)
+	}
+	return issues
+}
+
+// validateDashboardPanelPositions checks that panels don't overlap.
+// The panels of a collapsed row are checked among themselves, since they are only laid out when the row is expanded.
+func validateDashboardPanelPositions(row string, panels []interface{}) []string {
+	type rect struct {
+		name       string
+		x, y, w, h float64
+	}
+	var issues []string
+	var placed []rect
+	for _, p := range panels {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if nested, ok := panel["panels"].([]interface{}); ok && len(nested) > 0 {
+			issues = append(issues, validateDashboardPanelPositions(describePanel(panel), nested)...)
+		}
+		gridPos, ok := panel["gridPos"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		x, _ := gridPos["x"].(float64)
+		y, _ := gridPos["y"].(float64)
+		w, _ := gridPos["w"].(float64)
+		h, _ := gridPos["h"].(float64)
+		current := rect{describePanel(panel), x, y, w, h}
+		for _, other := range placed {
+			if current.x < other.x+other.w && other.x < current.x+current.w && current.y < other.y+other.h && other.y < current.y+current.h {
+				issue := fmt.Sprintf("panels %s and %s overlap", other.name, current.name)
+				if row != "" {
+					issue += fmt.Sprintf(" in row %s", row)
+				}
+				issues = append(issues, issue)
+			}
+		}
+		placed = append(placed, current)
+	}
+	return issues
+}
+
+// validateDashboardDatasources checks that the data sources referenced by variables, panels, targets and annotations
+// are either dashboard variables or data sources of the instance (if they could be listed).
+func validateDashboardDatasources(model map[string]interface{}, vc *dashboardValidationContext) []string {
+	variables := map[string]bool{}
+	var variableList []interface{}
+	if templating, ok := model["templating"].(map[string]interface{}); ok {
+		variableList, _ = templating["list"].([]interface{})
+	}
+	for _, v := range variableList {
+		if variable, ok := v.(map[string]interface{}); ok {
+			if name, ok := variable["name"].(string); ok {
+				variables[name] = true
+			}
+		}
+	}
+
+	issuesSet := map[string]bool{}
+	check := func(owner string, ref interface{}) {
+		var ds string
+		switch r := ref.(type) {
+		case string:
+			ds = r
+		case map[string]interface{}:
+			ds, _ = r["uid"].(string)
+		}
+		if ds == "" || builtinDatasourceUIDs[ds] {
+			return
+		}
+		if match := dashboardVariableRefRegexp.FindStringSubmatch(ds); match != nil {
+			name := match[1] + match[2]
+			if !variables[name] && !strings.HasPrefix(name, "__") {
+				issuesSet[fmt.Sprintf("%s references the data source variable %s, which isn't defined", owner, ds)] = true
+			}
+			return
+		}
+		if vc.datasources != nil && !vc.datasources[ds] {
+			issuesSet[fmt.Sprintf("%s references the data source %s, which doesn't exist", owner, ds)] = true
+		}
+	}
+
+	for _, v := range variableList {
+		if variable, ok := v.(map[string]interface{}); ok {
+			check(fmt.Sprintf("variable %v", variable["name"]), variable["datasource"])
+		}
+	}
+	panels, _ := model["panels"].([]interface{})
+	for _, panel := range allDashboardPanels(panels) {
+		owner := "panel " + describePanel(panel)
+		check(owner, panel["datasource"])
+		targets, _ := panel["targets"].([]interface{})
+		for _, t := range targets {
+			if target, ok := t.(map[string]interface{}); ok {
+				check(owner, target["datasource"])
+			}
+		}
+	}
+	if annotations, ok := model["annotations"].(map[string]interface{}); ok {
+		list, _ := annotations["list"].([]interface{})
+		for _, a := range list {
+			if annotation, ok := a.(map[string]interface{}); ok {
+				check(fmt.Sprintf("annotation %v", annotation["name"]), annotation["datasource"])
+			}
+		}
+	}
+
+	issues := make([]string, 0, len(issuesSet))
+	for issue := range issuesSet {
+		issues = append(issues, issue)
+	}
+	sort.Strings(issues)
+	return issues
+}
+
+// allDashboardPanels returns the panels of a dashboard, including the ones within collapsed rows.
+func allDashboardPanels(panels []interface{}) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, p := range panels {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, panel)
+		if nested, ok := panel["panels"].([]interface{}); ok {
+			result = append(result, allDashboardPanels(nested)...)
+		}
+	}
+	return result
+}
+
+func describePanel(panel map[string]interface{}) string {
+	if title, ok := panel["title"].(string); ok && title != "" {
+		return fmt.Sprintf("%q", title)
+	}
+	if id, ok := panel["id"].(float64); ok {
+		return fmt.Sprintf("#%v", id)
+	}
+	return "(untitled)"
+}
@@ -0,0 +1,792 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	dashboardJSONSchemaVersion = 38
+	dashboardGridWidth         = 24
+	defaultPanelWidth          = 12
+	defaultPanelHeight         = 8
+)
+
+func DatasourceDashboardJSON() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Builds a dashboard model from typed blocks, to be used in the ` + "`config_json`" + ` attribute of ` + "`grafana_dashboard`" + `.
+No API call is made.
+
+Panels without a position (` + "`x` and `y`" + `) are laid out automatically: left to right, then top to bottom.
+Top-level panels are placed first, followed by the rows and their panels.
+Panels with an explicit position are placed as-is, and the panels laid out automatically are placed around them.
+
+* [Dashboard JSON model](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/view-dashboard-json-model/)
+`,
+		ReadContext: dataSourceReadDashboardJSON,
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The title of the dashboard.",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The unique identifier of the dashboard. If not set, Grafana generates one.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the dashboard.",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The tags of the dashboard.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"editable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the dashboard can be edited in the UI.",
+			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "browser",
+				Description: "The timezone of the dashboard. For example, `browser` or `utc`.",
+			},
+			"refresh": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The auto-refresh interval of the dashboard. For example, `30s`.",
+			},
+			"time_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "now-6h",
+				Description: "The start of the default time range.",
+			},
+			"time_to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "now",
+				Description: "The end of the default time range.",
+			},
+			"panel": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The panels that aren't in a row. They are placed above the rows.",
+				Elem:        dashboardJSONPanelSchema(),
+			},
+			"row": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The rows of the dashboard, in order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The title of the row.",
+						},
+						"collapsed": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the row is collapsed.",
+						},
+						"panel": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The panels of the row.",
+							Elem:        dashboardJSONPanelSchema(),
+						},
+					},
+				},
+			},
+			"variable": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The template variables of the dashboard.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the variable, used to reference it as `$name`.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"query", "custom", "datasource", "interval"}, false),
+							Description:  "The type of the variable. Can be `query`, `custom`, `datasource` or `interval`.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The label of the variable.",
+						},
+						"query": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: "For `query` variables, the query of the data source. For `custom` and `interval` variables, the comma-separated values. " +
+								"For `datasource` variables, the type of data source (e.g. `prometheus`).",
+						},
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "For `query` variables, the UID of the data source to query. Can reference a `datasource` variable, e.g. `${ds}`.",
+						},
+						"datasource_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "For `query` variables, the type of the data source to query.",
+						},
+						"multi": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether multiple values can be selected.",
+						},
+						"include_all": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether to include an `All` option.",
+						},
+						"hide": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "none",
+							ValidateFunc: validation.StringInSlice([]string{"none", "label", "variable"}, false),
+							Description:  "What to hide in the variable's selector. Can be `none`, `label` or `variable`.",
+						},
+					},
+				},
+			},
+			"annotation": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The annotation queries of the dashboard.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the annotation query.",
+						},
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The UID of the data source to query.",
+						},
+						"datasource_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The type of the data source to query.",
+						},
+						"expr": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The query expression.",
+						},
+						"enable": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the annotations are shown by default.",
+						},
+						"hide": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether to hide the toggle of the annotation query.",
+						},
+						"icon_color": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "red",
+							Description: "The color of the annotations.",
+						},
+					},
+				},
+			},
+			"link": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The links of the dashboard.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The title of the link.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "link",
+							ValidateFunc: validation.StringInSlice([]string{"link", "dashboards"}, false),
+							Description:  "The type of link. `link` for a URL, `dashboards` for the dashboards with the given tags.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL of `link` links.",
+						},
+						"tags": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The tags of the dashboards of `dashboards` links.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"as_dropdown": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether to show `dashboards` links as a dropdown.",
+						},
+						"target_blank": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether to open the link in a new tab.",
+						},
+					},
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The dashboard model JSON.",
+			},
+		},
+	}
+}
+
+func dashboardJSONPanelSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The type of panel. For example, `timeseries`, `stat` or `table`.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The title of the panel.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the panel.",
+			},
+			"datasource_uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The UID of the data source of the panel. Can reference a `datasource` variable, e.g. `${ds}`.",
+			},
+			"datasource_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The type of the data source of the panel.",
+			},
+			"x": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, dashboardGridWidth-1),
+				Description:  "The horizontal position of the panel. The panel is placed automatically if `x` or `y` isn't set.",
+			},
+			"y": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The vertical position of the panel. The panel is placed automatically if `x` or `y` isn't set.",
+			},
+			"width": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultPanelWidth,
+				ValidateFunc: validation.IntBetween(1, dashboardGridWidth),
+				Description:  "The width of the panel, out of 24 columns.",
+			},
+			"height": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultPanelHeight,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The height of the panel, in grid units (30 pixels).",
+			},
+			"target": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The queries of the panel.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ref_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The reference of the query. Defaults to the next reference that isn't used by another target: `A` to `Z`, then `AA`, `AB`...",
+						},
+						"expr": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The query expression, for data sources such as Prometheus or Loki.",
+						},
+						"legend_format": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The legend format of the query's series.",
+						},
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The UID of the data source of the query, if it's not the panel's.",
+						},
+						"datasource_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The type of the data source of the query.",
+						},
+						"model_json": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+							Description:  "Other attributes of the query, as a JSON object, for data sources that don't use `expr`.",
+						},
+					},
+				},
+			},
+			"unit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The unit of the panel's values. For example, `percent` or `bytes`.",
+			},
+			"min": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Description: "The minimum of the panel's values.",
+			},
+			"max": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Description: "The maximum of the panel's values.",
+			},
+			"decimals": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of decimals of the panel's values.",
+			},
+			"field_config_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The field config of the panel, as a JSON object. `unit`, `min`, `max` and `decimals` are set in its defaults.",
+			},
+			"options_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The options of the panel, as a JSON object.",
+			},
+		},
+	}
+}
+
+// dashboardLayout places panels on the dashboard grid.
+type dashboardLayout struct {
+	x, y, lineHeight int
+	nextID           int
+	// reserved are the positions of the panels placed explicitly, which the panels placed automatically must not overlap.
+	reserved []dashboardGridPos
+}
+
+type dashboardGridPos struct {
+	x, y, w, h int
+}
+
+// reserve marks the positions of the panels with an explicit position as taken.
+func (l *dashboardLayout) reserve(panels []interface{}, raw cty.Value) {
+	for i, p := range panels {
+		panel := p.(map[string]interface{})
+		rawPanel := rawListElement(raw, "panel", i)
+		x, xOk := rawNumber(rawPanel, "x")
+		y, yOk := rawNumber(rawPanel, "y")
+		if xOk && yOk {
+			l.reserved = append(l.reserved, dashboardGridPos{x: int(x), y: int(y), w: panel["width"].(int), h: panel["height"].(int)})
+		}
+	}
+}
+
+// place returns the position of a panel of the given size, after the previously placed panels and around the reserved positions.
+func (l *dashboardLayout) place(width, height int) map[string]interface{} {
+	for {
+		if l.x+width > dashboardGridWidth {
+			if l.lineHeight == 0 {
+				// Nothing was placed on this line: try the next grid line
+				l.y++
+			}
+			l.newLine()
+		}
+		taken := l.overlap(dashboardGridPos{x: l.x, y: l.y, w: width, h: height})
+		if taken == nil {
+			break
+		}
+		l.x = taken.x + taken.w
+	}
+	pos := map[string]interface{}{"x": l.x, "y": l.y, "w": width, "h": height}
+	l.x += width
+	if height > l.lineHeight {
+		l.lineHeight = height
+	}
+	return pos
+}
+
+// overlap returns the reserved position that overlaps the given one, if any.
+func (l *dashboardLayout) overlap(pos dashboardGridPos) *dashboardGridPos {
+	for i, r := range l.reserved {
+		if pos.x < r.x+r.w && r.x < pos.x+pos.w && pos.y < r.y+r.h && r.y < pos.y+pos.h {
+			return &l.reserved[i]
+		}
+	}
+	return nil
+}
+
+func (l *dashboardLayout) newLine() {
+	if l.x > 0 {
+		l.y += l.lineHeight
+	}
+	l.x, l.lineHeight = 0, 0
+}
+
+func (l *dashboardLayout) id() int {
+	l.nextID++
+	return l.nextID
+}
+
+func dataSourceReadDashboardJSON(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	raw := d.GetRawConfig()
+
+	model := map[string]interface{}{
+		"title":         d.Get("title").(string),
+		"editable":      d.Get("editable").(bool),
+		"timezone":      d.Get("timezone").(string),
+		"schemaVersion": dashboardJSONSchemaVersion,
+		"time": map[string]interface{}{
+			"from": d.Get("time_from").(string),
+			"to":   d.Get("time_to").(string),
+		},
+		"tags": common.ListToStringSlice(d.Get("tags").([]interface{})),
+	}
+	for key, attr := range map[string]string{"uid": "uid", "description": "description", "refresh": "refresh"} {
+		if v := d.Get(attr).(string); v != "" {
+			model[key] = v
+		}
+	}
+
+	// The panels of collapsed rows aren't on the grid until the row is expanded
+	layout := &dashboardLayout{}
+	layout.reserve(d.Get("panel").([]interface{}), raw)
+	for i, r := range d.Get("row").([]interface{}) {
+		if row := r.(map[string]interface{}); !row["collapsed"].(bool) {
+			layout.reserve(row["panel"].([]interface{}), rawListElement(raw, "row", i))
+		}
+	}
+
+	panels := []interface{}{}
+	for i, p := range d.Get("panel").([]interface{}) {
+		panel, err := makeDashboardJSONPanel(p.(map[string]interface{}), rawListElement(raw, "panel", i), layout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		panels = append(panels, panel)
+	}
+	for i, r := range d.Get("row").([]interface{}) {
+		row := r.(map[string]interface{})
+		rawRow := rawListElement(raw, "row", i)
+
+		layout.newLine()
+		rowPanel := map[string]interface{}{
+			"id":        layout.id(),
+			"type":      "row",
+			"title":     row["title"].(string),
+			"collapsed": row["collapsed"].(bool),
+			"gridPos":   layout.place(dashboardGridWidth, 1),
+			"panels":    []interface{}{},
+		}
+		layout.newLine()
+		panels = append(panels, rowPanel)
+
+		// The panels of a collapsed row are nested in it, and the next row follows right below it
+		rowEnd := *layout
+		var rowPanels []interface{}
+		for j, p := range row["panel"].([]interface{}) {
+			panel, err := makeDashboardJSONPanel(p.(map[string]interface{}), rawListElement(rawRow, "panel", j), layout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			rowPanels = append(rowPanels, panel)
+		}
+		if row["collapsed"].(bool) {
+			if rowPanels != nil {
+				rowPanel["panels"] = rowPanels
+			}
+			rowEnd.nextID = layout.nextID
+			*layout = rowEnd
+		} else {
+			panels = append(panels, rowPanels...)
+		}
+	}
+	model["panels"] = panels
+
+	variables := []interface{}{}
+	for _, v := range d.Get("variable").([]interface{}) {
+		variables = append(variables, makeDashboardJSONVariable(v.(map[string]interface{})))
+	}
+	model["templating"] = map[string]interface{}{"list": variables}
+
+	annotations := []interface{}{}
+	for _, a := range d.Get("annotation").([]interface{}) {
+		annotation := a.(map[string]interface{})
+		item := map[string]interface{}{
+			"name":       annotation["name"].(string),
+			"datasource": makeDashboardJSONDatasourceRef(annotation["datasource_uid"].(string), annotation["datasource_type"].(string)),
+			"enable":     annotation["enable"].(bool),
+			"hide":       annotation["hide"].(bool),
+			"iconColor":  annotation["icon_color"].(string),
+		}
+		if expr := annotation["expr"].(string); expr != "" {
+			item["expr"] = expr
+		}
+		annotations = append(annotations, item)
+	}
+	model["annotations"] = map[string]interface{}{"list": annotations}
+
+	links := []interface{}{}
+	for _, l := range d.Get("link").([]interface{}) {
+		link := l.(map[string]interface{})
+		item := map[string]interface{}{
+			"title":       link["title"].(string),
+			"type":        link["type"].(string),
+			"tags":        common.ListToStringSlice(link["tags"].([]interface{})),
+			"asDropdown":  link["as_dropdown"].(bool),
+			"targetBlank": link["target_blank"].(bool),
+			"icon":        "external link",
+		}
+		if link["type"].(string) == "dashboards" {
+			item["icon"] = "dashboard"
+		}
+		if url := link["url"].(string); url != "" {
+			item["url"] = url
+		}
+		links = append(links, item)
+	}
+	model["links"] = links
+
+	modelJSON, err := json.Marshal(model)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(sha256JSON(string(modelJSON)))
+	d.Set("json", string(modelJSON))
+
+	return nil
+}
+
+// dashboardTargetRefID returns the i-th refId of the targets of a panel, the same way as Grafana: A to Z, then AA, AB, and so on.
+func dashboardTargetRefID(i int) string {
+	refID := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		refID = string(rune('A'+(i-1)%26)) + refID
+	}
+	return refID
+}
+
+func makeDashboardJSONPanel(panel map[string]interface{}, raw cty.Value, layout *dashboardLayout) (map[string]interface{}, error) {
+	width, height := panel["width"].(int), panel["height"].(int)
+	var gridPos map[string]interface{}
+	x, xOk := rawNumber(raw, "x")
+	y, yOk := rawNumber(raw, "y")
+	if xOk && yOk {
+		gridPos = map[string]interface{}{"x": int(x), "y": int(y), "w": width, "h": height}
+	} else {
+		gridPos = layout.place(width, height)
+	}
+
+	result := map[string]interface{}{
+		"id":      layout.id(),
+		"type":    panel["type"].(string),
+		"title":   panel["title"].(string),
+		"gridPos": gridPos,
+	}
+	if description := panel["description"].(string); description != "" {
+		result["description"] = description
+	}
+	if ds := makeDashboardJSONDatasourceRef(panel["datasource_uid"].(string), panel["datasource_type"].(string)); ds != nil {
+		result["datasource"] = ds
+	}
+
+	usedRefIDs := map[string]bool{}
+	for _, t := range panel["target"].([]interface{}) {
+		usedRefIDs[t.(map[string]interface{})["ref_id"].(string)] = true
+	}
+	nextRefID := 0
+	targets := []interface{}{}
+	for _, t := range panel["target"].([]interface{}) {
+		target := t.(map[string]interface{})
+		item := map[string]interface{}{}
+		if modelJSON := target["model_json"].(string); modelJSON != "" {
+			if err := json.Unmarshal([]byte(modelJSON), &item); err != nil {
+				return nil, fmt.Errorf("invalid model_json in a target of panel %q: %w", panel["title"], err)
+			}
+		}
+		// Targets without a refId get the next one that isn't set on another target
+		refID := target["ref_id"].(string)
+		for refID == "" {
+			if candidate := dashboardTargetRefID(nextRefID); !usedRefIDs[candidate] {
+				refID = candidate
+			}
+			nextRefID++
+		}
+		item["refId"] = refID
+		if expr := target["expr"].(string); expr != "" {
+			item["expr"] = expr
+		}
+		if legendFormat := target["legend_format"].(string); legendFormat != "" {
+			item["legendFormat"] = legendFormat
+		}
+		if ds := makeDashboardJSONDatasourceRef(target["datasource_uid"].(string), target["datasource_type"].(string)); ds != nil {
+			item["datasource"] = ds
+		}
+		targets = append(targets, item)
+	}
+	result["targets"] = targets
+
+	fieldConfig := map[string]interface{}{}
+	if fieldConfigJSON := panel["field_config_json"].(string); fieldConfigJSON != "" {
+		if err := json.Unmarshal([]byte(fieldConfigJSON), &fieldConfig); err != nil {
+			return nil, fmt.Errorf("invalid field_config_json in panel %q: %w", panel["title"], err)
+		}
+	}
+	defaults, _ := fieldConfig["defaults"].(map[string]interface{})
+	if defaults == nil {
+		defaults = map[string]interface{}{}
+	}
+	if unit := panel["unit"].(string); unit != "" {
+		defaults["unit"] = unit
+	}
+	for _, attr := range []string{"min", "max", "decimals"} {
+		if v, ok := rawNumber(raw, attr); ok {
+			defaults[attr] = v
+		}
+	}
+	fieldConfig["defaults"] = defaults
+	if _, ok := fieldConfig["overrides"]; !ok {
+		fieldConfig["overrides"] = []interface{}{}
+	}
+	result["fieldConfig"] = fieldConfig
+
+	if optionsJSON := panel["options_json"].(string); optionsJSON != "" {
+		options := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(optionsJSON), &options); err != nil {
+			return nil, fmt.Errorf("invalid options_json in panel %q: %w", panel["title"], err)
+		}
+		result["options"] = options
+	}
+
+	return result, nil
+}
+
+func makeDashboardJSONVariable(variable map[string]interface{}) map[string]interface{} {
+	variableType, query := variable["type"].(string), variable["query"].(string)
+	result := map[string]interface{}{
+		"name":       variable["name"].(string),
+		"type":       variableType,
+		"query":      query,
+		"multi":      variable["multi"].(bool),
+		"includeAll": variable["include_all"].(bool),
+		"hide":       map[string]int{"none": 0, "label": 1, "variable": 2}[variable["hide"].(string)],
+	}
+	if label := variable["label"].(string); label != "" {
+		result["label"] = label
+	}
+
+	switch variableType {
+	case "query":
+		result["definition"] = query
+		result["refresh"] = 1 // On dashboard load
+		result["datasource"] = makeDashboardJSONDatasourceRef(variable["datasource_uid"].(string), variable["datasource_type"].(string))
+		result["options"] = []interface{}{}
+	case "datasource":
+		result["refresh"] = 1
+		result["options"] = []interface{}{}
+	case "custom", "interval":
+		// The options of these variables are saved in the model
+		options := []interface{}{}
+		for i, value := range strings.Split(query, ",") {
+			value = strings.TrimSpace(value)
+			options = append(options, map[string]interface{}{"text": value, "value": value, "selected": i == 0})
+		}
+		result["options"] = options
+		if len(options) > 0 {
+			current := options[0].(map[string]interface{})
+			result["current"] = map[string]interface{}{"text": current["text"], "value": current["value"]}
+		}
+	}
+
+	return result
+}
+
+func makeDashboardJSONDatasourceRef(uid, datasourceType string) map[string]interface{} {
+	if uid == "" && datasourceType == "" {
+		return nil
+	}
+	ref := map[string]interface{}{}
+	if uid != "" {
+		ref["uid"] = uid
+	}
+	if datasourceType != "" {
+		ref["type"] = datasourceType
+	}
+	return ref
+}
+
+// rawListElement returns the configuration of the i-th block of the given type. It may be null or unknown.
+func rawListElement(raw cty.Value, key string, i int) cty.Value {
+	if !raw.IsKnown() || raw.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	list := raw.GetAttr(key)
+	if !list.IsKnown() || list.IsNull() || list.LengthInt() <= i {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return list.Index(cty.NumberIntVal(int64(i)))
+}
+
+// rawNumber returns a number attribute from the configuration of a block, telling whether it's set.
+// Unlike `d.Get`, it can tell zero from unset.
+func rawNumber(raw cty.Value, key string) (float64, bool) {
+	if !raw.IsKnown() || raw.IsNull() {
+		return 0, false
+	}
+	v := raw.GetAttr(key)
+	if !v.IsKnown() || v.IsNull() {
+		return 0, false
+	}
+	f, _ := new(big.Float).Set(v.AsBigFloat()).Float64()
+	return f, true
+}
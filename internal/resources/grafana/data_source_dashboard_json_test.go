@@ -0,0 +1,162 @@
+package grafana_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDatasourceDashboardJSON_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard, 0),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_dashboard_json/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "uid", "test-ds-dashboard-json-uid"),
+					testAccDashboardJSONCheckLayout("data.grafana_dashboard_json.test", map[string][4]float64{
+						"Uptime":        {0, 0, 6, 4},
+						"Requests":      {6, 0, 18, 8},
+						"Details":       {0, 8, 24, 1},
+						"Top endpoints": {0, 9, 24, 8},
+						"Debug":         {0, 17, 24, 1},
+					}),
+					testAccDashboardJSONCheck("data.grafana_dashboard_json.test", func(model map[string]interface{}) error {
+						panels := model["panels"].([]interface{})
+						uptime := panels[0].(map[string]interface{})
+						defaults := uptime["fieldConfig"].(map[string]interface{})["defaults"].(map[string]interface{})
+						if defaults["unit"] != "percent" || defaults["min"] != 0.0 || defaults["max"] != 100.0 {
+							return fmt.Errorf("unexpected field config defaults: %v", defaults)
+						}
+						requests := panels[1].(map[string]interface{})
+						targets := requests["targets"].([]interface{})
+						if len(targets) != 2 || targets[1].(map[string]interface{})["refId"] != "B" {
+							return fmt.Errorf("unexpected targets: %v", targets)
+						}
+						debug := panels[4].(map[string]interface{})
+						if nested := debug["panels"].([]interface{}); len(nested) != 1 {
+							return fmt.Errorf("expected the collapsed row to hold 1 panel, got %d", len(nested))
+						}
+						variables := model["templating"].(map[string]interface{})["list"].([]interface{})
+						if options := variables[1].(map[string]interface{})["options"].([]interface{}); len(options) != 3 {
+							return fmt.Errorf("expected 3 interval options, got %d", len(options))
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatasourceDashboardJSON_layout(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "grafana_dashboard_json" "test" {
+  title = "Layout"
+
+  panel {
+    type   = "text"
+    title  = "Auto 1"
+    width  = 12
+    height = 8
+  }
+
+  panel {
+    type   = "text"
+    title  = "Auto 2"
+    width  = 12
+    height = 8
+
+    target {
+      ref_id = "A"
+    }
+    dynamic "target" {
+      for_each = range(27)
+      content {
+        expr = "up"
+      }
+    }
+  }
+
+  panel {
+    type   = "text"
+    title  = "Fixed"
+    x      = 6
+    y      = 0
+    width  = 12
+    height = 4
+  }
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					// Panels placed automatically don't overlap the ones with a position
+					testAccDashboardJSONCheckLayout("data.grafana_dashboard_json.test", map[string][4]float64{
+						"Auto 1": {0, 4, 12, 8},
+						"Auto 2": {12, 4, 12, 8},
+						"Fixed":  {6, 0, 12, 4},
+					}),
+					testAccDashboardJSONCheck("data.grafana_dashboard_json.test", func(model map[string]interface{}) error {
+						targets := model["panels"].([]interface{})[1].(map[string]interface{})["targets"].([]interface{})
+						refIDs := map[string]bool{}
+						for _, target := range targets {
+							refIDs[target.(map[string]interface{})["refId"].(string)] = true
+						}
+						if len(refIDs) != 28 || !refIDs["B"] || !refIDs["Z"] || !refIDs["AA"] || !refIDs["AB"] {
+							return fmt.Errorf("expected 28 distinct refIds, from A to AB, got %v", refIDs)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDashboardJSONCheck(name string, check func(model map[string]interface{}) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+		var model map[string]interface{}
+		if err := json.Unmarshal([]byte(rs.Primary.Attributes["json"]), &model); err != nil {
+			return err
+		}
+		return check(model)
+	}
+}
+
+// testAccDashboardJSONCheckLayout checks the gridPos (x, y, w, h) of the top-level panels, by title.
+func testAccDashboardJSONCheckLayout(name string, expected map[string][4]float64) resource.TestCheckFunc {
+	return testAccDashboardJSONCheck(name, func(model map[string]interface{}) error {
+		for _, p := range model["panels"].([]interface{}) {
+			panel := p.(map[string]interface{})
+			title := panel["title"].(string)
+			want, ok := expected[title]
+			if !ok {
+				return fmt.Errorf("unexpected panel: %s", title)
+			}
+			gridPos := panel["gridPos"].(map[string]interface{})
+			got := [4]float64{gridPos["x"].(float64), gridPos["y"].(float64), gridPos["w"].(float64), gridPos["h"].(float64)}
+			if got != want {
+				return fmt.Errorf("panel %s: expected gridPos %v, got %v", title, want, got)
+			}
+		}
+		return nil
+	})
+}
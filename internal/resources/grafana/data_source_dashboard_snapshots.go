@@ -0,0 +1,117 @@
+package grafana
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/client/snapshots"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceDashboardSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Datasource for listing the dashboard snapshots of an organization.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/share-dashboards-panels/#publish-a-snapshot)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/snapshot/)
+`,
+		ReadContext: dataSourceReadDashboardSnapshots,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"query": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the snapshots whose name contains this string.",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1000,
+				Description: "Maximum number of snapshots to return.",
+			},
+			"snapshots": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The snapshots.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the snapshot.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the snapshot.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL of the snapshot. For external snapshots, the URL on the external server.",
+						},
+						"external": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the snapshot is saved on an external server.",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The timestamp when the snapshot was created, in RFC3339 format.",
+						},
+						"expires": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The timestamp when the snapshot expires, in RFC3339 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReadDashboardSnapshots(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	metaClient := meta.(*common.Client)
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+	query := d.Get("query").(string)
+	limit := int64(d.Get("limit").(int))
+
+	params := snapshots.NewSearchDashboardSnapshotsParams().WithLimit(&limit)
+	if query != "" {
+		params.SetQuery(&query)
+	}
+	resp, err := client.Snapshots.SearchDashboardSnapshots(params, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results := make([]interface{}, len(resp.Payload))
+	for i, snapshot := range resp.Payload {
+		url := metaClient.GrafanaSubpath("/dashboard/snapshot/" + snapshot.Key)
+		if snapshot.External {
+			url = snapshot.ExternalURL
+		}
+		results[i] = map[string]interface{}{
+			"key":      snapshot.Key,
+			"name":     snapshot.Name,
+			"url":      url,
+			"external": snapshot.External,
+			"created":  time.Time(snapshot.Created).Format(time.RFC3339),
+			"expires":  time.Time(snapshot.Expires).Format(time.RFC3339),
+		}
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, query))
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	if err := d.Set("snapshots", results); err != nil {
+		return diag.Errorf("error setting snapshots attribute: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package grafana
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/client/dashboard_versions"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceDashboardVersions() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Datasource for retrieving the version history of a dashboard, most recent first.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-version-history/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard_versions/)
+`,
+		ReadContext: dataSourceReadDashboardVersions,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"dashboard_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The UID of the dashboard.",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1000,
+				Description: "Maximum number of versions to return.",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The versions of the dashboard, most recent first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version number.",
+						},
+						"parent_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version number that this version was created from.",
+						},
+						"restored_from": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version number that was restored to create this version. `0` if this version wasn't created by a restore.",
+						},
+						"author": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The login of the user who saved this version.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The commit message of this version.",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The timestamp when this version was saved, in RFC3339 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReadDashboardVersions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+	uid := d.Get("dashboard_uid").(string)
+	limit := int64(d.Get("limit").(int))
+
+	params := dashboard_versions.NewGetDashboardVersionsByUIDParams().WithUID(uid).WithLimit(&limit)
+	resp, err := client.DashboardVersions.GetDashboardVersionsByUID(params, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	versions := make([]interface{}, len(resp.Payload))
+	for i, version := range resp.Payload {
+		versions[i] = map[string]interface{}{
+			"version":        version.Version,
+			"parent_version": version.ParentVersion,
+			"restored_from":  version.RestoredFrom,
+			"author":         version.CreatedBy,
+			"message":        version.Message,
+			"created":        time.Time(version.Created).Format(time.RFC3339),
+		}
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, uid))
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	if err := d.Set("versions", versions); err != nil {
+		return diag.Errorf("error setting versions attribute: %s", err)
+	}
+
+	return nil
+}
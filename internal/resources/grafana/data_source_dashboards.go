@@ -8,15 +8,19 @@ import (
 	"sort"
 	"strings"
 
+	gapi "github.com/grafana/grafana-api-golang-client"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/terraform-provider-grafana/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func DatasourceDashboards() *schema.Resource {
 	return &schema.Resource{
 		Description: `
-Datasource for retrieving all dashboards. Specify list of folder IDs to search in for dashboards.
+Datasource for searching dashboards (or folders), by text, folder, tags, UIDs or starred status.
+Results are paged through automatically, up to ` + "`limit`" + `.
 
 * [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/)
 * [Folder/Dashboard Search HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/folder_dashboard_search/)
@@ -24,11 +28,55 @@ Datasource for retrieving all dashboards. Specify list of folder IDs to search i
 `,
 		ReadContext: dataSourceReadDashboards,
 		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the results whose title contains this text.",
+			},
 			"folder_ids": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"folder_uids"},
+				Description:   "Numerical IDs of Grafana folders containing dashboards. Specify to filter for dashboards by folder (eg. `[0]` for General folder), or leave blank to get all dashboards in all folders.",
+				Elem:          &schema.Schema{Type: schema.TypeInt},
+			},
+			"folder_uids": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"folder_ids"},
+				Description:   "UIDs of Grafana folders containing dashboards. Specify to filter for dashboards by folder (eg. `[\"general\"]` for General folder), or leave blank to get all dashboards in all folders.",
+				Elem:          &schema.Schema{Type: schema.TypeString},
+			},
+			"include_subfolders": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to also search the nested subfolders of `folder_uids`, recursively.",
+			},
+			"dashboard_uids": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "Numerical IDs of Grafana folders containing dashboards. Specify to filter for dashboards by folder (eg. `[0]` for General folder), or leave blank to get all dashboards in all folders.",
-				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Only return the dashboards with these UIDs.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"starred": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only return the dashboards starred by the user of the provider.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "dash-db",
+				ValidateFunc: validation.StringInSlice([]string{"dash-db", "dash-folder"}, false),
+				Description:  "The type of results: `dash-db` for dashboards, `dash-folder` for folders.",
+			},
+			"sort": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"alpha-asc", "alpha-desc"}, false),
+				Description:  "The order of the results: `alpha-asc` or `alpha-desc`. By default, results are sorted alphabetically, folders first.",
 			},
 			"limit": {
 				Type:        schema.TypeInt,
@@ -59,6 +107,19 @@ Datasource for retrieving all dashboards. Specify list of folder IDs to search i
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"folder_uid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
 					},
 				},
 			},
@@ -82,32 +143,93 @@ func HashDashboardSearchParameters(params map[string][]string) string {
 	return fmt.Sprintf("%x", hashOut.Sum(nil))[:23]
 }
 
+// dashboardSearchPageSize is the maximum number of results that Grafana returns per search request.
+const dashboardSearchPageSize = 5000
+
 func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*common.Client).GrafanaAPI
+	metaClient := meta.(*common.Client)
+	client := metaClient.GrafanaAPI
 	var diags diag.Diagnostics
+	limit := d.Get("limit").(int)
 	params := url.Values{
-		"limit": {fmt.Sprint(d.Get("limit"))},
-		"type":  {"dash-db"},
+		"limit": {fmt.Sprint(limit)},
+		"type":  {d.Get("type").(string)},
 	}
 
-	// add tags and folder IDs from attributes to dashboard search parameters
+	// add search attributes to dashboard search parameters
+	if query := d.Get("query").(string); query != "" {
+		params.Set("query", query)
+	}
 	if list, ok := d.GetOk("folder_ids"); ok {
 		for _, elem := range list.([]interface{}) {
 			params.Add("folderIds", fmt.Sprint(elem))
 		}
 	}
-
+	if list, ok := d.GetOk("folder_uids"); ok {
+		for _, elem := range list.([]interface{}) {
+			params.Add("folderUIDs", fmt.Sprint(elem))
+		}
+	}
+	if list, ok := d.GetOk("dashboard_uids"); ok {
+		for _, elem := range list.([]interface{}) {
+			params.Add("dashboardUIDs", fmt.Sprint(elem))
+		}
+	}
 	if list, ok := d.GetOk("tags"); ok {
 		for _, elem := range list.([]interface{}) {
 			params.Add("tag", fmt.Sprint(elem))
 		}
 	}
+	if d.Get("starred").(bool) {
+		params.Set("starred", "true")
+	}
+	if sort := d.Get("sort").(string); sort != "" {
+		params.Set("sort", sort)
+	}
+	if !d.Get("include_subfolders").(bool) {
+		params.Set("includeSubfolders", "false")
+	}
 
 	d.SetId(HashDashboardSearchParameters(params))
 
-	results, err := client.FolderDashboardSearch(params)
-	if err != nil {
-		return diag.FromErr(err)
+	// The search API doesn't search within subfolders, so they are added to the searched folders
+	searchParams := url.Values{}
+	for k, v := range params {
+		if k != "includeSubfolders" {
+			searchParams[k] = v
+		}
+	}
+	if d.Get("include_subfolders").(bool) {
+		for _, uid := range params["folderUIDs"] {
+			if uid == "general" {
+				continue
+			}
+			subfolderUIDs, err := listSubfolderUIDs(metaClient.GrafanaOAPI, uid)
+			if err != nil {
+				return diag.Errorf("failed to list the subfolders of folder %s: %s", uid, err)
+			}
+			for _, subfolderUID := range subfolderUIDs {
+				searchParams.Add("folderUIDs", subfolderUID)
+			}
+		}
+	}
+
+	var results []gapi.FolderDashboardSearchResponse
+	for page := 1; len(results) < limit; page++ {
+		pageSize := dashboardSearchPageSize
+		if remaining := limit - len(results); remaining < pageSize {
+			pageSize = remaining
+		}
+		searchParams.Set("limit", fmt.Sprint(pageSize))
+		searchParams.Set("page", fmt.Sprint(page))
+		pageResults, err := client.FolderDashboardSearch(searchParams)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		results = append(results, pageResults...)
+		if len(pageResults) < pageSize {
+			break
+		}
 	}
 
 	dashboards := make([]map[string]interface{}, len(results))
@@ -116,6 +238,9 @@ func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta
 			"title":        result.Title,
 			"uid":          result.UID,
 			"folder_title": result.FolderTitle,
+			"folder_uid":   result.FolderUID,
+			"url":          metaClient.GrafanaSubpath(result.URL),
+			"tags":         result.Tags,
 		}
 	}
 
@@ -125,3 +250,20 @@ func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta
 
 	return diags
 }
+
+// listSubfolderUIDs returns the UIDs of the nested subfolders of a folder, recursively.
+func listSubfolderUIDs(client *goapi.GrafanaHTTPAPI, uid string) ([]string, error) {
+	subfolders, err := listSubfolders(client, uid)
+	if err != nil {
+		return nil, err
+	}
+	var uids []string
+	for _, subfolder := range subfolders {
+		nested, err := listSubfolderUIDs(client, subfolder.UID)
+		if err != nil {
+			return nil, err
+		}
+		uids = append(append(uids, subfolder.UID), nested...)
+	}
+	return uids, nil
+}
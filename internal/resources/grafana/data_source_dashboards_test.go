@@ -1,11 +1,13 @@
 package grafana_test
 
 import (
+	"fmt"
 	"net/url"
 	"testing"
 
 	"github.com/grafana/terraform-provider-grafana/internal/resources/grafana"
 	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -28,6 +30,14 @@ func TestAccDataSourceDashboardsAllAndByFolderID(t *testing.T) {
 		resource.TestCheckResourceAttr("data.grafana_dashboards.folder_ids", "dashboards.#", "1"),
 		resource.TestCheckResourceAttr("data.grafana_dashboards.folder_ids_tags", "dashboards.#", "1"),
 		resource.TestCheckResourceAttr("data.grafana_dashboards.limit_one", "dashboards.#", "1"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.folder_uids_query", "dashboards.#", "1"),
+		resource.TestCheckResourceAttrPair("data.grafana_dashboards.folder_uids_query", "dashboards.0.folder_uid", "grafana_folder.data_source_dashboards", "uid"),
+		resource.TestCheckResourceAttrPair("data.grafana_dashboards.folder_uids_query", "dashboards.0.url", "grafana_dashboard.data_source_dashboards1", "url"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.folder_uids_query", "dashboards.0.tags.#", "1"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.folder_uids_query", "dashboards.0.tags.0", "data_source_dashboards"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.dashboard_uids", "dashboards.#", "2"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.dashboard_uids", "dashboards.0.title", "data_source_dashboards 2"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.dashboard_uids", "dashboards.1.title", "data_source_dashboards 1"),
 	}
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -40,3 +50,48 @@ func TestAccDataSourceDashboardsAllAndByFolderID(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceDashboards_nestedFolders(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t) // TODO: Switch to OSS once nested folders are enabled by default
+
+	name := acctest.RandString(10)
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "grafana_folder" "parent" {
+	title = "%[1]s parent"
+}
+
+resource "grafana_folder" "child" {
+	title             = "%[1]s child"
+	parent_folder_uid = grafana_folder.parent.uid
+}
+
+resource "grafana_dashboard" "child" {
+	folder      = grafana_folder.child.uid
+	config_json = jsonencode({
+		title = "%[1]s dashboard"
+	})
+}
+
+data "grafana_dashboards" "nested" {
+	folder_uids = [grafana_folder.parent.uid]
+	depends_on  = [grafana_dashboard.child]
+}
+
+data "grafana_dashboards" "not_nested" {
+	folder_uids        = [grafana_folder.parent.uid]
+	include_subfolders = false
+	depends_on         = [grafana_dashboard.child]
+}`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_dashboards.nested", "dashboards.#", "1"),
+					resource.TestCheckResourceAttrPair("data.grafana_dashboards.nested", "dashboards.0.folder_uid", "grafana_folder.child", "uid"),
+					resource.TestCheckResourceAttr("data.grafana_dashboards.not_nested", "dashboards.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -11,32 +11,43 @@ import (
 )
 
 func DatasourceDatasource() *schema.Resource {
+	updates := map[string]*schema.Schema{
+		"org_id": orgIDAttribute(),
+		"id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			AtLeastOneOf: []string{"id", "name", "uid"},
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			AtLeastOneOf: []string{"id", "name", "uid"},
+		},
+		"uid": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			AtLeastOneOf: []string{"id", "name", "uid"},
+		},
+		"secure_json_data_encoded": nil,
+		"http_headers":             nil,
+		"secure_json_data_version": nil,
+		"secure_json_data_hashes":  nil,
+		"health_check":             nil,
+		"health_status":            nil,
+		"health_message":           nil,
+	}
+	// The whole JSON data is returned in `json_data_encoded`
+	for _, block := range datasourceConfigBlocks {
+		updates[block.name] = nil
+	}
+
 	return &schema.Resource{
 		Description: "Get details about a Grafana Datasource querying by either name, uid or ID",
 		ReadContext: datasourceDatasourceRead,
-		Schema: common.CloneResourceSchemaForDatasource(ResourceDataSource(), map[string]*schema.Schema{
-			"org_id": orgIDAttribute(),
-			"id": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				AtLeastOneOf: []string{"id", "name", "uid"},
-			},
-			"name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				AtLeastOneOf: []string{"id", "name", "uid"},
-			},
-			"uid": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				AtLeastOneOf: []string{"id", "name", "uid"},
-			},
-			"secure_json_data_encoded": nil,
-			"http_headers":             nil,
-		}),
+		Schema:      common.CloneResourceSchemaForDatasource(ResourceDataSource(), updates),
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/folders"
@@ -23,9 +24,23 @@ func DatasourceFolder() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"org_id": orgIDAttribute(),
 			"title": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"title", "path"},
+				Description:  "The name of the Grafana folder. With nested folders, titles may not be unique. Use `path` to find a nested folder.",
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The path of the Grafana folder: the titles of its parent folders and its own title, separated by slashes. " +
+					"For example, `Platform/Networking/Edge`.",
+			},
+			"parent_folder_uid": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the Grafana folder.",
+				Computed:    true,
+				Description: "The uid of the parent folder. Empty if the folder is at the root.",
 			},
 			"id": {
 				Type:        schema.TypeInt,
@@ -75,11 +90,53 @@ func findFolderWithTitle(client *goapi.GrafanaHTTPAPI, title string) (*models.Fo
 	}
 }
 
+// findFolderWithPath walks down the folder hierarchy, following the titles of the given slash-separated path.
+func findFolderWithPath(client *goapi.GrafanaHTTPAPI, path string) (*models.Folder, error) {
+	parentUID := ""
+	for _, title := range strings.Split(strings.Trim(path, "/"), "/") {
+		subfolders, err := listSubfolders(client, parentUID)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, folder := range subfolders {
+			if folder.Title == title {
+				parentUID, found = folder.UID, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("folder with path %s not found: no folder titled %s", path, title)
+		}
+	}
+
+	resp, err := client.Folders.GetFolderByUID(folders.NewGetFolderByUIDParams().WithFolderUID(parentUID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Payload, nil
+}
+
+// folderPath returns the path of a folder, from the titles of its parents.
+func folderPath(folder *models.Folder) string {
+	titles := make([]string, 0, len(folder.Parents)+1)
+	for _, parent := range folder.Parents {
+		titles = append(titles, parent.Title)
+	}
+	return strings.Join(append(titles, folder.Title), "/")
+}
+
 func dataSourceFolderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	metaClient := meta.(*common.Client)
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 
-	folder, err := findFolderWithTitle(client, d.Get("title").(string))
+	var folder *models.Folder
+	var err error
+	if path, ok := d.GetOk("path"); ok {
+		folder, err = findFolderWithPath(client, path.(string))
+	} else {
+		folder, err = findFolderWithTitle(client, d.Get("title").(string))
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -88,6 +145,8 @@ func dataSourceFolderRead(ctx context.Context, d *schema.ResourceData, meta inte
 	d.Set("org_id", strconv.FormatInt(orgID, 10))
 	d.Set("uid", folder.UID)
 	d.Set("title", folder.Title)
+	d.Set("path", folderPath(folder))
+	d.Set("parent_folder_uid", folder.ParentUID)
 	d.Set("url", metaClient.GrafanaSubpath(folder.URL))
 
 	return nil
@@ -1,6 +1,7 @@
 package grafana_test
 
 import (
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	goapi "github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/internal/common"
 	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -29,6 +31,15 @@ func TestAccDatasourceFolder_basic(t *testing.T) {
 		resource.TestCheckResourceAttr(
 			"data.grafana_folder.from_title", "url", strings.TrimRight(os.Getenv("GRAFANA_URL"), "/")+"/dashboards/f/test-ds-folder-uid/test-folder",
 		),
+		resource.TestCheckResourceAttr(
+			"data.grafana_folder.from_title", "path", "test-folder",
+		),
+		resource.TestCheckResourceAttr(
+			"data.grafana_folder.from_path", "uid", "test-ds-folder-uid",
+		),
+		resource.TestCheckResourceAttr(
+			"data.grafana_folder.from_path", "title", "test-folder",
+		),
 	}
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -42,3 +53,37 @@ func TestAccDatasourceFolder_basic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDatasourceFolder_nestedPath(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t) // TODO: Switch to OSS once nested folders are enabled by default
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "grafana_folder" "parent" {
+	title = "%[1]s"
+}
+
+resource "grafana_folder" "child" {
+	title             = "Child"
+	uid               = "%[1]s-child"
+	parent_folder_uid = grafana_folder.parent.uid
+}
+
+data "grafana_folder" "from_path" {
+	path = "%[1]s/${grafana_folder.child.title}"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_folder.from_path", "uid", name+"-child"),
+					resource.TestCheckResourceAttr("data.grafana_folder.from_path", "path", name+"/Child"),
+					resource.TestCheckResourceAttrPair("data.grafana_folder.from_path", "parent_folder_uid", "grafana_folder.parent", "uid"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,122 @@
+package grafana
+
+import (
+	"context"
+	"strconv"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceFolderTree() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Datasource for retrieving the full hierarchy of (nested) folders of an organization.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/manage-dashboards/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/folder/)
+`,
+		ReadContext: dataSourceReadFolderTree,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"folders": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All the folders of the organization, parents before their children.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The uid of the folder.",
+						},
+						"title": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The title of the folder.",
+						},
+						"parent_uid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The uid of the parent folder. Empty for folders at the root.",
+						},
+						"depth": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The nesting depth of the folder. `0` for folders at the root.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The titles of the folder's parents and its own title, separated by slashes. For example, `Platform/Networking/Edge`.",
+						},
+					},
+				},
+			},
+			"uids_by_path": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of folder paths to folder uids. Folders with the same path (identical titles under the same parent) are omitted.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceReadFolderTree(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	var folders []interface{}
+	if err := walkFolderTree(client, "", "", 0, &folders); err != nil {
+		return diag.Errorf("failed to list folders: %s", err)
+	}
+
+	uidsByPath := map[string]interface{}{}
+	duplicatePaths := map[string]bool{}
+	for _, f := range folders {
+		folder := f.(map[string]interface{})
+		path := folder["path"].(string)
+		if _, ok := uidsByPath[path]; ok {
+			duplicatePaths[path] = true
+		}
+		uidsByPath[path] = folder["uid"]
+	}
+	for path := range duplicatePaths {
+		delete(uidsByPath, path)
+	}
+
+	d.SetId(strconv.FormatInt(orgID, 10))
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	if err := d.Set("folders", folders); err != nil {
+		return diag.Errorf("error setting folders attribute: %s", err)
+	}
+	d.Set("uids_by_path", uidsByPath)
+
+	return nil
+}
+
+// walkFolderTree appends the subfolders of the given folder to the result, recursively.
+func walkFolderTree(client *goapi.GrafanaHTTPAPI, parentUID, parentPath string, depth int, result *[]interface{}) error {
+	subfolders, err := listSubfolders(client, parentUID)
+	if err != nil {
+		return err
+	}
+	for _, folder := range subfolders {
+		path := folder.Title
+		if parentPath != "" {
+			path = parentPath + "/" + folder.Title
+		}
+		*result = append(*result, map[string]interface{}{
+			"uid":        folder.UID,
+			"title":      folder.Title,
+			"parent_uid": parentUID,
+			"depth":      depth,
+			"path":       path,
+		})
+		if err := walkFolderTree(client, folder.UID, path, depth+1, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
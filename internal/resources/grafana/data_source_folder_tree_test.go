@@ -0,0 +1,31 @@
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceFolderTree_basic(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t) // TODO: Switch to OSS once nested folders are enabled by default
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_folder_tree/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.grafana_folder_tree.all", "uids_by_path.Platform", "grafana_folder.platform", "uid"),
+					resource.TestCheckResourceAttrPair("data.grafana_folder_tree.all", "uids_by_path.Platform/Networking", "grafana_folder.networking", "uid"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.grafana_folder_tree.all", "folders.*", map[string]string{
+						"title": "Networking",
+						"depth": "1",
+						"path":  "Platform/Networking",
+					}),
+					resource.TestCheckResourceAttrPair("grafana_dashboard.edge", "folder", "grafana_folder.networking", "uid"),
+				),
+			},
+		},
+	})
+}
@@ -24,6 +24,8 @@ func DatasourceLibraryPanel() *schema.Resource {
 				Optional:    true,
 				Description: "The unique identifier (UID) of the library panel.",
 			},
+			"store_sha256": nil,
+			"force_delete": nil,
 		}),
 	}
 }
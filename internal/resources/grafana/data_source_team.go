@@ -29,7 +29,8 @@ func DatasourceTeam() *schema.Resource {
 				Default:     false,
 				Description: "Whether to read the team sync settings. This is only available in Grafana Enterprise.",
 			},
-			"ignore_externally_synced_members": nil,
+			"ignore_externally_synced_members":  nil,
+			"ignore_externally_managed_members": nil,
 		}),
 	}
 }
@@ -0,0 +1,334 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// datasourceConfigField maps an attribute of a typed configuration block to a jsonData (or secureJsonData) key.
+type datasourceConfigField struct {
+	attr string
+	// key is the jsonData key. Nested keys are separated by dots.
+	key         string
+	valueType   schema.ValueType
+	description string
+	validate    schema.SchemaValidateFunc
+	// secure fields are sent in secureJsonData. Grafana doesn't return them, so they are never read back.
+	secure bool
+}
+
+// datasourceConfigBlock is a typed configuration block for a type of data source.
+type datasourceConfigBlock struct {
+	name string
+	// types are the data source types that the block can be used with.
+	types  []string
+	fields []datasourceConfigField
+}
+
+var datasourceConfigBlocks = []datasourceConfigBlock{
+	{
+		name:  "prometheus",
+		types: []string{"prometheus"},
+		fields: []datasourceConfigField{
+			{attr: "http_method", key: "httpMethod", valueType: schema.TypeString, description: "The HTTP method used to query: `GET` or `POST`.", validate: validation.StringInSlice([]string{"GET", "POST"}, false)},
+			{attr: "time_interval", key: "timeInterval", valueType: schema.TypeString, description: "The scrape interval of Prometheus, used as the lower limit of the query step. For example, `15s`."},
+			{attr: "query_timeout", key: "queryTimeout", valueType: schema.TypeString, description: "The timeout of queries. For example, `60s`."},
+			{attr: "prometheus_type", key: "prometheusType", valueType: schema.TypeString, description: "The type of Prometheus server: `Prometheus`, `Cortex`, `Mimir` or `Thanos`.", validate: validation.StringInSlice([]string{"Prometheus", "Cortex", "Mimir", "Thanos"}, false)},
+			{attr: "prometheus_version", key: "prometheusVersion", valueType: schema.TypeString, description: "The version of the Prometheus server. For example, `2.40.0`."},
+			{attr: "cache_level", key: "cacheLevel", valueType: schema.TypeString, description: "The caching level of queries: `Low`, `Medium`, `High` or `None`.", validate: validation.StringInSlice([]string{"Low", "Medium", "High", "None"}, false)},
+			{attr: "custom_query_parameters", key: "customQueryParameters", valueType: schema.TypeString, description: "Custom parameters added to the query URLs. For example, `max_source_resolution=5m&timeout=10`."},
+			{attr: "disable_recording_rules", key: "disableRecordingRules", valueType: schema.TypeBool, description: "Whether to skip loading the recording rules in the query editor."},
+			{attr: "incremental_querying", key: "incrementalQuerying", valueType: schema.TypeBool, description: "Whether to cache query results and only query new data."},
+			{attr: "manage_alerts", key: "manageAlerts", valueType: schema.TypeBool, description: "Whether to manage the alerts of this data source in the alerting UI."},
+		},
+	},
+	{
+		name:  "loki",
+		types: []string{"loki"},
+		fields: []datasourceConfigField{
+			{attr: "max_lines", key: "maxLines", valueType: schema.TypeInt, description: "The maximum number of log lines returned by queries.", validate: validation.IntAtLeast(1)},
+			{attr: "timeout", key: "timeout", valueType: schema.TypeInt, description: "The timeout of queries, in seconds.", validate: validation.IntAtLeast(1)},
+			{attr: "manage_alerts", key: "manageAlerts", valueType: schema.TypeBool, description: "Whether to manage the alerts of this data source in the alerting UI."},
+			{attr: "alertmanager_uid", key: "alertmanagerUid", valueType: schema.TypeString, description: "The UID of the Alertmanager data source that the ruler is associated with."},
+		},
+	},
+	{
+		name:  "tempo",
+		types: []string{"tempo"},
+		fields: []datasourceConfigField{
+			{attr: "traces_to_logs_datasource_uid", key: "tracesToLogsV2.datasourceUid", valueType: schema.TypeString, description: "The UID of the logs data source to link traces to."},
+			{attr: "traces_to_metrics_datasource_uid", key: "tracesToMetrics.datasourceUid", valueType: schema.TypeString, description: "The UID of the metrics data source to link traces to."},
+			{attr: "service_map_datasource_uid", key: "serviceMap.datasourceUid", valueType: schema.TypeString, description: "The UID of the Prometheus data source holding the service graph metrics."},
+			{attr: "loki_search_datasource_uid", key: "lokiSearch.datasourceUid", valueType: schema.TypeString, description: "The UID of the Loki data source used to search traces."},
+			{attr: "node_graph_enabled", key: "nodeGraph.enabled", valueType: schema.TypeBool, description: "Whether to show the node graph of traces."},
+			{attr: "search_hide", key: "search.hide", valueType: schema.TypeBool, description: "Whether to hide the search query type."},
+			{attr: "span_bar_type", key: "spanBar.type", valueType: schema.TypeString, description: "The information shown next to the span bar: `None`, `Duration` or `Tag`.", validate: validation.StringInSlice([]string{"None", "Duration", "Tag"}, false)},
+		},
+	},
+	{
+		name:  "elasticsearch",
+		types: []string{"elasticsearch"},
+		fields: []datasourceConfigField{
+			{attr: "index", key: "index", valueType: schema.TypeString, description: "The index name or pattern. For example, `[logs-]YYYY.MM.DD`."},
+			{attr: "time_field", key: "timeField", valueType: schema.TypeString, description: "The name of the time field. For example, `@timestamp`."},
+			{attr: "interval", key: "interval", valueType: schema.TypeString, description: "The interval of the index pattern: `Hourly`, `Daily`, `Weekly`, `Monthly` or `Yearly`.", validate: validation.StringInSlice([]string{"Hourly", "Daily", "Weekly", "Monthly", "Yearly"}, false)},
+			{attr: "time_interval", key: "timeInterval", valueType: schema.TypeString, description: "The lower limit of the auto group by time interval. For example, `10s`."},
+			{attr: "max_concurrent_shard_requests", key: "maxConcurrentShardRequests", valueType: schema.TypeInt, description: "The maximum number of concurrent shard requests of each query.", validate: validation.IntAtLeast(1)},
+			{attr: "log_message_field", key: "logMessageField", valueType: schema.TypeString, description: "The field holding the log message."},
+			{attr: "log_level_field", key: "logLevelField", valueType: schema.TypeString, description: "The field holding the log level."},
+			{attr: "include_frozen", key: "includeFrozen", valueType: schema.TypeBool, description: "Whether to include frozen indices in searches."},
+		},
+	},
+	{
+		name:  "postgres",
+		types: []string{"postgres", "grafana-postgresql-datasource"},
+		fields: []datasourceConfigField{
+			{attr: "database", key: "database", valueType: schema.TypeString, description: "The name of the database."},
+			{attr: "ssl_mode", key: "sslmode", valueType: schema.TypeString, description: "The SSL mode: `disable`, `require`, `verify-ca` or `verify-full`.", validate: validation.StringInSlice([]string{"disable", "require", "verify-ca", "verify-full"}, false)},
+			{attr: "postgres_version", key: "postgresVersion", valueType: schema.TypeInt, description: "The version of PostgreSQL, as a number. For example, `1500` for 15."},
+			{attr: "timescaledb", key: "timescaledb", valueType: schema.TypeBool, description: "Whether the database uses the TimescaleDB extension."},
+			{attr: "max_open_conns", key: "maxOpenConns", valueType: schema.TypeInt, description: "The maximum number of open connections to the database.", validate: validation.IntAtLeast(0)},
+			{attr: "max_idle_conns", key: "maxIdleConns", valueType: schema.TypeInt, description: "The maximum number of idle connections to the database.", validate: validation.IntAtLeast(0)},
+			{attr: "conn_max_lifetime", key: "connMaxLifetime", valueType: schema.TypeInt, description: "The maximum lifetime of connections, in seconds.", validate: validation.IntAtLeast(0)},
+			{attr: "time_interval", key: "timeInterval", valueType: schema.TypeString, description: "The lower limit of the auto group by time interval. For example, `1m`."},
+			{attr: "password", key: "password", valueType: schema.TypeString, description: "The password of the database user.", secure: true},
+		},
+	},
+	{
+		name:  "cloudwatch",
+		types: []string{"cloudwatch"},
+		fields: []datasourceConfigField{
+			{attr: "auth_type", key: "authType", valueType: schema.TypeString, description: "The authentication provider: `default`, `keys`, `credentials`, `ec2_iam_role` or `grafana_assume_role`.", validate: validation.StringInSlice([]string{"default", "keys", "credentials", "ec2_iam_role", "grafana_assume_role"}, false)},
+			{attr: "default_region", key: "defaultRegion", valueType: schema.TypeString, description: "The default AWS region. For example, `us-east-1`."},
+			{attr: "assume_role_arn", key: "assumeRoleArn", valueType: schema.TypeString, description: "The ARN of the role to assume."},
+			{attr: "external_id", key: "externalId", valueType: schema.TypeString, description: "The external ID used to assume the role."},
+			{attr: "profile", key: "profile", valueType: schema.TypeString, description: "The name of the credentials profile, for the `credentials` authentication provider."},
+			{attr: "custom_metrics_namespaces", key: "customMetricsNamespaces", valueType: schema.TypeString, description: "Comma-separated custom metrics namespaces."},
+			{attr: "endpoint", key: "endpoint", valueType: schema.TypeString, description: "A custom endpoint for the CloudWatch API."},
+			{attr: "access_key", key: "accessKey", valueType: schema.TypeString, description: "The access key ID, for the `keys` authentication provider.", secure: true},
+			{attr: "secret_key", key: "secretKey", valueType: schema.TypeString, description: "The secret access key, for the `keys` authentication provider.", secure: true},
+		},
+	},
+	{
+		name:  "influxdb",
+		types: []string{"influxdb"},
+		fields: []datasourceConfigField{
+			{attr: "version", key: "version", valueType: schema.TypeString, description: "The query language: `InfluxQL`, `Flux` or `SQL`.", validate: validation.StringInSlice([]string{"InfluxQL", "Flux", "SQL"}, false)},
+			{attr: "organization", key: "organization", valueType: schema.TypeString, description: "The organization, for the `Flux` query language."},
+			{attr: "default_bucket", key: "defaultBucket", valueType: schema.TypeString, description: "The default bucket, for the `Flux` query language."},
+			{attr: "http_mode", key: "httpMode", valueType: schema.TypeString, description: "The HTTP method used to query: `GET` or `POST`.", validate: validation.StringInSlice([]string{"GET", "POST"}, false)},
+			{attr: "time_interval", key: "timeInterval", valueType: schema.TypeString, description: "The lower limit of the auto group by time interval. For example, `10s`."},
+			{attr: "max_series", key: "maxSeries", valueType: schema.TypeInt, description: "The maximum number of series shown.", validate: validation.IntAtLeast(1)},
+			{attr: "token", key: "token", valueType: schema.TypeString, description: "The token, for the `Flux` and `SQL` query languages.", secure: true},
+			{attr: "password", key: "password", valueType: schema.TypeString, description: "The password of the database user, for the `InfluxQL` query language.", secure: true},
+		},
+	},
+}
+
+// datasourceConfigBlockSchemas returns the schemas of the typed configuration blocks, keyed by name.
+func datasourceConfigBlockSchemas() map[string]*schema.Schema {
+	var names []string
+	for _, block := range datasourceConfigBlocks {
+		names = append(names, block.name)
+	}
+
+	schemas := map[string]*schema.Schema{}
+	for _, block := range datasourceConfigBlocks {
+		var conflicts []string
+		for _, name := range names {
+			if name != block.name {
+				conflicts = append(conflicts, name)
+			}
+		}
+
+		fields := map[string]*schema.Schema{}
+		for _, field := range block.fields {
+			fields[field.attr] = &schema.Schema{
+				Type:         field.valueType,
+				Optional:     true,
+				Sensitive:    field.secure,
+				ValidateFunc: field.validate,
+				Description:  fmt.Sprintf("%s Sets `%s` in the %s.", field.description, field.key, field.dataName()),
+			}
+		}
+
+		schemas[block.name] = &schema.Schema{
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: conflicts,
+			Description: fmt.Sprintf("Typed configuration for data sources of type %s. The keys it sets can't be set in `json_data_encoded` or `secure_json_data_encoded`.",
+				"`"+strings.Join(block.types, "` or `")+"`"),
+			Elem: &schema.Resource{Schema: fields},
+		}
+	}
+	return schemas
+}
+
+func (f datasourceConfigField) dataName() string {
+	if f.secure {
+		return "secure JSON data"
+	}
+	return "JSON data"
+}
+
+// configuredDatasourceConfigBlock returns the typed configuration block set on the data source, if any.
+func configuredDatasourceConfigBlock(d interface{ Get(string) interface{} }) *datasourceConfigBlock {
+	for i, block := range datasourceConfigBlocks {
+		if list, ok := d.Get(block.name).([]interface{}); ok && len(list) > 0 {
+			return &datasourceConfigBlocks[i]
+		}
+	}
+	return nil
+}
+
+// validateDatasourceConfigBlock checks that the typed configuration block matches the type of the data source,
+// and that its keys aren't also set in the encoded JSON data.
+func validateDatasourceConfigBlock(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	block := configuredDatasourceConfigBlock(d)
+	if block == nil {
+		return nil
+	}
+
+	if d.NewValueKnown("type") {
+		dsType := d.Get("type").(string)
+		found := false
+		for _, t := range block.types {
+			found = found || t == dsType
+		}
+		if !found {
+			return fmt.Errorf("the %s block can't be used with data sources of type %s", block.name, dsType)
+		}
+	}
+
+	for _, encoded := range []struct {
+		attr   string
+		secure bool
+	}{{"json_data_encoded", false}, {"secure_json_data_encoded", true}} {
+		if !d.NewValueKnown(encoded.attr) || d.Get(encoded.attr).(string) == "" {
+			continue
+		}
+		data := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(d.Get(encoded.attr).(string)), &data); err != nil {
+			continue
+		}
+		var duplicates []string
+		for _, field := range block.fields {
+			if _, ok := popNestedJSONValue(data, field.key); ok && field.secure == encoded.secure {
+				duplicates = append(duplicates, field.key)
+			}
+		}
+		if len(duplicates) > 0 {
+			sort.Strings(duplicates)
+			return fmt.Errorf("%s sets keys managed by the %s block: %s", encoded.attr, block.name, strings.Join(duplicates, ", "))
+		}
+	}
+
+	return nil
+}
+
+// applyDatasourceConfigBlock sets the keys of the configured typed block in the JSON data and secure JSON data.
+// Only the attributes set in the configuration are sent, so that unset attributes keep Grafana's defaults.
+func applyDatasourceConfigBlock(d *schema.ResourceData, jsonData, secureJSONData map[string]interface{}) {
+	block := configuredDatasourceConfigBlock(d)
+	if block == nil {
+		return
+	}
+	values := d.Get(block.name).([]interface{})[0].(map[string]interface{})
+	rawBlock := rawListElement(d.GetRawConfig(), block.name, 0)
+
+	for _, field := range block.fields {
+		if !rawAttributeSet(rawBlock, field.attr) {
+			continue
+		}
+		if field.secure {
+			setNestedJSONValue(secureJSONData, field.key, values[field.attr])
+		} else {
+			setNestedJSONValue(jsonData, field.key, values[field.attr])
+		}
+	}
+}
+
+// readDatasourceConfigBlock maps the keys of the typed block in the state back from the JSON data, and removes them from it
+// so that they aren't also in `json_data_encoded`. Secure fields can't be read, so their values are kept.
+func readDatasourceConfigBlock(d *schema.ResourceData, jsonData map[string]interface{}) error {
+	block := configuredDatasourceConfigBlock(d)
+	if block == nil {
+		return nil
+	}
+	current := d.Get(block.name).([]interface{})[0]
+	currentValues, _ := current.(map[string]interface{})
+
+	values := map[string]interface{}{}
+	for _, field := range block.fields {
+		if field.secure {
+			values[field.attr] = currentValues[field.attr]
+			continue
+		}
+		value, ok := popNestedJSONValue(jsonData, field.key)
+		if !ok {
+			continue
+		}
+		switch field.valueType {
+		case schema.TypeInt:
+			if f, ok := value.(float64); ok {
+				value = int(f)
+			}
+		case schema.TypeString:
+			if _, ok := value.(string); !ok {
+				value = fmt.Sprint(value)
+			}
+		}
+		values[field.attr] = value
+	}
+
+	return d.Set(block.name, []interface{}{values})
+}
+
+func setNestedJSONValue(data map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := data[part].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			data[part] = next
+		}
+		data = next
+	}
+	data[parts[len(parts)-1]] = value
+}
+
+// popNestedJSONValue removes a nested key from JSON data and returns its value. Parent objects left empty are removed too.
+func popNestedJSONValue(data map[string]interface{}, key string) (interface{}, bool) {
+	parts := strings.SplitN(key, ".", 2)
+	if len(parts) == 1 {
+		value, ok := data[key]
+		delete(data, key)
+		return value, ok
+	}
+	nested, ok := data[parts[0]].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	value, ok := popNestedJSONValue(nested, parts[1])
+	if len(nested) == 0 {
+		delete(data, parts[0])
+	}
+	return value, ok
+}
+
+// rawAttributeSet tells whether an attribute of a block is set in the configuration.
+func rawAttributeSet(raw cty.Value, key string) bool {
+	if !raw.IsKnown() || raw.IsNull() {
+		return false
+	}
+	v := raw.GetAttr(key)
+	return !v.IsKnown() || !v.IsNull()
+}
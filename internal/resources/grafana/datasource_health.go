@@ -0,0 +1,100 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// datasourceHealth is the result of a data source health check.
+type datasourceHealth struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// checkDatasourceHealth calls the health check of a data source.
+// Failing health checks are returned as results rather than errors: Grafana responds to them with a 400 status and the same payload.
+func checkDatasourceHealth(client *goapi.GrafanaHTTPAPI, uid string, timeout time.Duration) (*datasourceHealth, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	path := "/datasources/uid/" + uid + "/health"
+	result, err := client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "GET " + path,
+		Method:             "GET",
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Context:            ctx,
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(resp runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			body, _ := io.ReadAll(resp.Body())
+			if resp.Code() != http.StatusOK && resp.Code() != http.StatusBadRequest {
+				return nil, fmt.Errorf("[GET %s] status: %d, body: %s", path, resp.Code(), body)
+			}
+			health := &datasourceHealth{}
+			if err := json.Unmarshal(body, health); err != nil {
+				return nil, fmt.Errorf("failed to decode the health check result: %w", err)
+			}
+			return health, nil
+		}),
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return &datasourceHealth{Status: "ERROR", Message: fmt.Sprintf("the health check timed out after %s", timeout)}, nil
+		}
+		return nil, err
+	}
+	return result.(*datasourceHealth), nil
+}
+
+// datasourceHealthCheckDiags runs the health check configured on the data source, if enabled, and stores its result.
+// An unhealthy data source fails the apply if `fail_on_error` is set, and is reported as a warning otherwise.
+func datasourceHealthCheckDiags(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	list := d.Get("health_check").([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		d.Set("health_status", "")
+		d.Set("health_message", "")
+		return nil
+	}
+	healthCheck := list[0].(map[string]interface{})
+	if !healthCheck["enabled"].(bool) {
+		d.Set("health_status", "")
+		d.Set("health_message", "")
+		return nil
+	}
+
+	client, _, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+	uid := d.Get("uid").(string)
+	health, err := checkDatasourceHealth(client, uid, time.Duration(healthCheck["timeout"].(int))*time.Second)
+	if err != nil {
+		return diag.Errorf("failed to check the health of data source %s: %s", uid, err)
+	}
+	d.Set("health_status", health.Status)
+	d.Set("health_message", health.Message)
+
+	if health.Status == "OK" {
+		return nil
+	}
+	severity := diag.Warning
+	if healthCheck["fail_on_error"].(bool) {
+		severity = diag.Error
+	}
+	return diag.Diagnostics{{
+		Severity: severity,
+		Summary:  fmt.Sprintf("data source %s failed its health check", d.Get("name")),
+		Detail:   fmt.Sprintf("Status: %s\nMessage: %s", health.Status, health.Message),
+	}}
+}
@@ -0,0 +1,165 @@
+package grafana
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// secureJSONDataHashes returns a salted hash of each field of the secure JSON data sent to Grafana:
+// the fields of `secure_json_data_encoded` and the secure fields of the typed configuration block.
+// The hashes are salted with the data source UID, so that the same secret doesn't have the same hash across data sources.
+func secureJSONDataHashes(d interface{ Get(string) interface{} }) (map[string]interface{}, error) {
+	data, err := makeSecureJSONData(d)
+	if err != nil {
+		return nil, err
+	}
+	// The configuration isn't available when reading, so the secure fields of the block that have a value are hashed
+	if block := configuredDatasourceConfigBlock(d); block != nil {
+		values, _ := d.Get(block.name).([]interface{})[0].(map[string]interface{})
+		for _, field := range block.fields {
+			if value, ok := values[field.attr].(string); field.secure && ok && value != "" {
+				setNestedJSONValue(data, field.key, value)
+			}
+		}
+	}
+
+	uid := d.Get("uid").(string)
+	hashes := map[string]interface{}{}
+	for key, value := range data {
+		valueJSON, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		mac := hmac.New(sha256.New, []byte(uid))
+		mac.Write([]byte(key))
+		mac.Write([]byte{0})
+		mac.Write(valueJSON)
+		hashes[key] = hex.EncodeToString(mac.Sum(nil))
+	}
+	return hashes, nil
+}
+
+// setSecureJSONDataHashes stores the hashes of the secure JSON data that was sent to Grafana.
+func setSecureJSONDataHashes(d *schema.ResourceData) error {
+	hashes, err := secureJSONDataHashes(d)
+	if err != nil {
+		return err
+	}
+	return d.Set("secure_json_data_hashes", hashes)
+}
+
+// diffSecureJSONData plans an update when the secure JSON data changes, even if its JSON is considered equivalent,
+// by comparing the hashes of the configured fields with the ones in the state.
+func diffSecureJSONData(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	old := d.Get("secure_json_data_hashes").(map[string]interface{})
+	if d.Id() != "" && len(old) == 0 {
+		// Nothing to compare to, for example in a state created before the hashes were added: they are set on the next read.
+		// An update is only planned if the secrets changed.
+		changed := d.HasChange("secure_json_data_encoded")
+		if block := configuredDatasourceConfigBlock(d); block != nil {
+			changed = changed || d.HasChange(block.name)
+		}
+		if !changed {
+			return nil
+		}
+	}
+
+	uid := d.Get("uid").(string)
+	known := d.NewValueKnown("secure_json_data_encoded") && d.NewValueKnown("uid") && uid != ""
+	if block := configuredDatasourceConfigBlock(d); block != nil {
+		for _, field := range block.fields {
+			known = known && (!field.secure || d.NewValueKnown(block.name+".0."+field.attr))
+		}
+	}
+	if !known {
+		return d.SetNewComputed("secure_json_data_hashes")
+	}
+	hashes, err := secureJSONDataHashes(d)
+	if err != nil {
+		return err
+	}
+	if len(old) == len(hashes) {
+		changed := false
+		for key, hash := range hashes {
+			if old[key] != hash {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			return nil
+		}
+	}
+	return d.SetNew("secure_json_data_hashes", hashes)
+}
+
+// readSecureJSONDataDrift compares the secure JSON data fields in the state with the fields that are set in Grafana.
+// Grafana doesn't return secret values, so only removed fields can be detected: they are removed from the state, so that they are set again on the next apply.
+// States without hashes, for example created before they were added, get the hashes of the secure JSON data in the state.
+func readSecureJSONDataDrift(d *schema.ResourceData, client *goapi.GrafanaHTTPAPI) error {
+	hashes := d.Get("secure_json_data_hashes").(map[string]interface{})
+	if len(hashes) == 0 {
+		return setSecureJSONDataHashes(d)
+	}
+
+	resp, err := client.Datasources.GetDataSourceByUID(datasources.NewGetDataSourceByUIDParams().WithUID(d.Get("uid").(string)), nil)
+	if err != nil {
+		return err
+	}
+	setFields := resp.Payload.SecureJSONFields
+
+	var data map[string]interface{}
+	if encoded := d.Get("secure_json_data_encoded").(string); encoded != "" {
+		if err := json.Unmarshal([]byte(encoded), &data); err != nil {
+			return fmt.Errorf("failed to unmarshal secure JSON data: %s", err)
+		}
+	}
+	block := configuredDatasourceConfigBlock(d)
+	var blockValues map[string]interface{}
+	if block != nil {
+		blockValues, _ = d.Get(block.name).([]interface{})[0].(map[string]interface{})
+	}
+	drifted := false
+	for key := range hashes {
+		if setFields[key] {
+			continue
+		}
+		delete(hashes, key)
+		delete(data, key)
+		if blockValues != nil {
+			for _, field := range block.fields {
+				if field.secure && field.key == key {
+					blockValues[field.attr] = ""
+				}
+			}
+		}
+		drifted = true
+	}
+	if !drifted {
+		return nil
+	}
+
+	encoded := ""
+	if len(data) > 0 {
+		encodedBytes, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		encoded = string(encodedBytes)
+	}
+	d.Set("secure_json_data_encoded", encoded)
+	if block != nil {
+		if err := d.Set(block.name, []interface{}{blockValues}); err != nil {
+			return err
+		}
+	}
+	return d.Set("secure_json_data_hashes", hashes)
+}
@@ -0,0 +1,221 @@
+package grafana
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
+	"github.com/grafana/grafana-openapi-client-go/client/folders"
+	"github.com/grafana/grafana-openapi-client-go/client/library_elements"
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
+	"github.com/grafana/grafana-openapi-client-go/client/search"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// folderContents is the content of a folder, including its nested subfolders.
+type folderContents struct {
+	uid   string
+	id    int64
+	title string
+	// path is the path of the folder, relative to the folder whose contents were listed.
+	path string
+
+	dashboards    []folderItem
+	alertRules    []folderItem
+	libraryPanels []folderItem
+	subfolders    []*folderContents
+}
+
+type folderItem struct {
+	uid   string
+	title string
+	// path is the path of the folder containing the item.
+	path string
+}
+
+func (c *folderContents) isEmpty() bool {
+	return len(c.dashboards) == 0 && len(c.alertRules) == 0 && len(c.libraryPanels) == 0 && len(c.subfolders) == 0
+}
+
+// listFolderContents lists the dashboards, alert rules, library panels and subfolders of a folder, recursively.
+func listFolderContents(client *goapi.GrafanaHTTPAPI, folder *models.Folder) (*folderContents, error) {
+	rulesResp, err := client.Provisioning.GetAlertRules(provisioning.NewGetAlertRulesParams(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert rules: %w", err)
+	}
+	rulesByFolder := map[string][]folderItem{}
+	for _, rule := range rulesResp.Payload {
+		rulesByFolder[*rule.FolderUID] = append(rulesByFolder[*rule.FolderUID], folderItem{uid: rule.UID, title: *rule.Title})
+	}
+
+	return listFolderContentsRecursive(client, folder.UID, folder.ID, folder.Title, "", rulesByFolder)
+}
+
+func listFolderContentsRecursive(client *goapi.GrafanaHTTPAPI, uid string, id int64, title, path string, rulesByFolder map[string][]folderItem) (*folderContents, error) {
+	contents := &folderContents{uid: uid, id: id, title: title, path: path}
+	itemPath := path
+	if itemPath == "" {
+		itemPath = title
+	}
+
+	for _, rule := range rulesByFolder[uid] {
+		rule.path = itemPath
+		contents.alertRules = append(contents.alertRules, rule)
+	}
+
+	// Dashboards
+	searchType := "dash-db"
+	limit := int64(1000)
+	for page := int64(1); ; page++ {
+		params := search.NewSearchParams().WithFolderUIDs([]string{uid}).WithType(&searchType).WithLimit(&limit).WithPage(&page)
+		resp, err := client.Search.Search(params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search for dashboards in folder %s: %w", uid, err)
+		}
+		for _, hit := range resp.Payload {
+			contents.dashboards = append(contents.dashboards, folderItem{uid: hit.UID, title: hit.Title, path: itemPath})
+		}
+		if int64(len(resp.Payload)) < limit {
+			break
+		}
+	}
+
+	// Library panels
+	folderFilter := strconv.FormatInt(id, 10)
+	perPage := int64(100)
+	for page := int64(1); ; page++ {
+		params := library_elements.NewGetLibraryElementsParams().WithFolderFilter(&folderFilter).WithPerPage(&perPage).WithPage(&page)
+		resp, err := client.LibraryElements.GetLibraryElements(params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list library panels in folder %s: %w", uid, err)
+		}
+		result := resp.Payload.Result
+		for _, panel := range result.Elements {
+			contents.libraryPanels = append(contents.libraryPanels, folderItem{uid: panel.UID, title: panel.Name, path: itemPath})
+		}
+		if int64(len(result.Elements)) < perPage {
+			break
+		}
+	}
+
+	subfolders, err := listSubfolders(client, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subfolders of folder %s: %w", uid, err)
+	}
+	for _, hit := range subfolders {
+		subfolder, err := listFolderContentsRecursive(client, hit.UID, hit.ID, hit.Title, itemPath+"/"+hit.Title, rulesByFolder)
+		if err != nil {
+			return nil, err
+		}
+		contents.subfolders = append(contents.subfolders, subfolder)
+	}
+
+	return contents, nil
+}
+
+// flatten returns the contents of the folder and of all of its subfolders, by type.
+func (c *folderContents) flatten() (dashboards, alertRules, libraryPanels, subfolders []folderItem) {
+	dashboards = append(dashboards, c.dashboards...)
+	alertRules = append(alertRules, c.alertRules...)
+	libraryPanels = append(libraryPanels, c.libraryPanels...)
+	for _, subfolder := range c.subfolders {
+		parentPath := subfolder.path[:strings.LastIndex(subfolder.path, "/")]
+		subfolders = append(subfolders, folderItem{uid: subfolder.uid, title: subfolder.title, path: parentPath})
+
+		d, a, l, s := subfolder.flatten()
+		dashboards = append(dashboards, d...)
+		alertRules = append(alertRules, a...)
+		libraryPanels = append(libraryPanels, l...)
+		subfolders = append(subfolders, s...)
+	}
+	return
+}
+
+// notEmptyFolderDiags describes the contents of a folder that can't be deleted because it isn't empty, grouped by type.
+func notEmptyFolderDiags(contents *folderContents) diag.Diagnostics {
+	dashboards, alertRules, libraryPanels, subfolders := contents.flatten()
+
+	var detail strings.Builder
+	for _, group := range []struct {
+		name  string
+		items []folderItem
+	}{
+		{"Dashboards", dashboards},
+		{"Alert rules", alertRules},
+		{"Library panels", libraryPanels},
+		{"Subfolders", subfolders},
+	} {
+		if len(group.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&detail, "%s (%d):\n", group.name, len(group.items))
+		for _, item := range group.items {
+			fmt.Fprintf(&detail, "  - %q (uid: %s) in %s\n", item.title, item.uid, item.path)
+		}
+	}
+	detail.WriteString("\nMove or delete these objects, or set `force_destroy` to delete them along with the folder.")
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("folder %s is not empty and prevent_destroy_if_not_empty is set", contents.uid),
+		Detail:   detail.String(),
+	}}
+}
+
+// deleteFolderContents deletes the contents of a folder in dependency order: alert rules and dashboards first,
+// then library panels (which can't be deleted while dashboards use them), then subfolders, deepest first.
+func deleteFolderContents(client *goapi.GrafanaHTTPAPI, contents *folderContents) error {
+	dashboardItems, alertRules, libraryPanels, subfolders := contents.flatten()
+
+	for _, rule := range alertRules {
+		if _, err := client.Provisioning.DeleteAlertRule(provisioning.NewDeleteAlertRuleParams().WithUID(rule.uid), nil); err != nil {
+			return fmt.Errorf("failed to delete alert rule %s: %w", rule.uid, err)
+		}
+	}
+	for _, dashboard := range dashboardItems {
+		if _, err := client.Dashboards.DeleteDashboardByUID(dashboards.NewDeleteDashboardByUIDParams().WithUID(dashboard.uid), nil); err != nil {
+			return fmt.Errorf("failed to delete dashboard %s: %w", dashboard.uid, err)
+		}
+	}
+	for _, panel := range libraryPanels {
+		if _, err := client.LibraryElements.DeleteLibraryElementByUID(library_elements.NewDeleteLibraryElementByUIDParams().WithLibraryElementUID(panel.uid), nil); err != nil {
+			return fmt.Errorf("failed to delete library panel %s: %w", panel.uid, err)
+		}
+	}
+	// Subfolders are listed parents first
+	for i := len(subfolders) - 1; i >= 0; i-- {
+		if _, err := client.Folders.DeleteFolder(folders.NewDeleteFolderParams().WithFolderUID(subfolders[i].uid), nil); err != nil {
+			return fmt.Errorf("failed to delete folder %s: %w", subfolders[i].uid, err)
+		}
+	}
+
+	return nil
+}
+
+// listSubfolders lists the folders directly under the given parent folder, or the root folders if the parent UID is empty.
+func listSubfolders(client *goapi.GrafanaHTTPAPI, parentUID string) ([]*models.FolderSearchHit, error) {
+	var result []*models.FolderSearchHit
+	limit := int64(1000)
+	for page := int64(1); ; page++ {
+		params := folders.NewGetFoldersParams().WithLimit(&limit).WithPage(&page)
+		if parentUID != "" {
+			params.SetParentUID(&parentUID)
+		}
+		resp, err := client.Folders.GetFolders(params, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, folder := range resp.Payload {
+			// Without nested folders, the parent UID is ignored and the root folders are returned
+			if folder.ParentUID == parentUID {
+				result = append(result, folder)
+			}
+		}
+		if int64(len(resp.Payload)) < limit {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package grafana
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+)
+
+// oapiRequest sends a request to an API endpoint (or payload) that the OpenAPI client doesn't support yet.
+// It goes through the client's transport so that authentication, org scoping, retries and TLS settings are respected.
+// The path is relative to the `/api` base path and must already be escaped.
+// Errors are formatted like the ones from the legacy client, so `common.IsNotFoundError` works on them.
+func oapiRequest(client *goapi.GrafanaHTTPAPI, method, path string, query url.Values, body, result interface{}) error {
+	_, err := client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 method + " " + path,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			for k, v := range query {
+				if err := r.SetQueryParam(k, v...); err != nil {
+					return err
+				}
+			}
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(resp runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if resp.Code() < http.StatusOK || resp.Code() >= http.StatusMultipleChoices {
+				respBody, _ := io.ReadAll(resp.Body())
+				return nil, fmt.Errorf("[%s %s] status: %d, body: %s", method, path, resp.Code(), respBody)
+			}
+			if result == nil {
+				return nil, nil
+			}
+			if err := consumer.Consume(resp.Body(), result); err != nil && !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			return nil, nil
+		}),
+	})
+	return err
+}
@@ -3,13 +3,17 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/terraform-provider-grafana/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -78,11 +82,7 @@ This resource requires Grafana 9.1.0 or later.
 							Default:          0,
 							Description:      "The amount of time for which the rule must be breached for the rule to be considered to be Firing. Before this time has elapsed, the rule is only considered to be Pending.",
 							ValidateDiagFunc: common.ValidateDurationWithDays,
-							DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-								oldDuration, _ := promModel.ParseDuration(oldValue)
-								newDuration, _ := promModel.ParseDuration(newValue)
-								return oldDuration == newDuration
-							},
+							DiffSuppressFunc: diffSuppressDuration,
 						},
 						"no_data_state": {
 							Type:        schema.TypeString,
@@ -179,6 +179,58 @@ This resource requires Grafana 9.1.0 or later.
 							Default:     false,
 							Description: "Sets whether the alert should be paused or not.",
 						},
+						"notification_settings": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Notification settings for the rule. If specified, the alerts of the rule are routed directly to the given contact point instead of going through the notification policy tree. This requires Grafana 10.4 or later, with the `alertingSimplifiedRouting` feature toggle enabled.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"contact_point": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The name of the contact point to send notifications to.",
+									},
+									"group_by": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Description: "A list of alert labels to group alerts into notifications by. Use the special label `...` to group alerts by all labels, effectively disabling grouping. If set, it must contain `alertname` and `grafana_folder`.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"group_wait": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Description:      "Time to wait to buffer alerts of the same group before sending a notification. If empty, the default of the root notification policy is used.",
+										ValidateDiagFunc: common.ValidateDurationWithDays,
+										DiffSuppressFunc: diffSuppressDuration,
+									},
+									"group_interval": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Description:      "Minimum time interval between two notifications for the same group. If empty, the default of the root notification policy is used.",
+										ValidateDiagFunc: common.ValidateDurationWithDays,
+										DiffSuppressFunc: diffSuppressDuration,
+									},
+									"repeat_interval": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Description:      "Minimum time interval for re-sending a notification if an alert is still firing. If empty, the default of the root notification policy is used.",
+										ValidateDiagFunc: common.ValidateDurationWithDays,
+										DiffSuppressFunc: diffSuppressDuration,
+									},
+									"mute_timings": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Description: "A list of mute timing names to apply to the alerts of the rule.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -187,11 +239,11 @@ This resource requires Grafana 9.1.0 or later.
 }
 
 func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, orgID, idStr := ClientFromExistingOrgResource(meta, data.Id())
+	client, orgID, idStr := OAPIClientFromExistingOrgResource(meta, data.Id())
 
 	key := UnpackGroupID(idStr)
 
-	group, err := client.AlertRuleGroup(key.FolderUID, key.Name)
+	group, err := getAlertRuleGroup(client, key)
 	if err, shouldReturn := common.CheckReadError("rule group", data, err); shouldReturn {
 		return err
 	}
@@ -206,6 +258,7 @@ func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta int
 
 func createAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := ClientFromNewOrgResource(meta, data)
+	oapiClient, _ := OAPIClientFromNewOrgResource(meta, data)
 
 	group, err := unpackRuleGroup(data)
 	if err != nil {
@@ -213,7 +266,10 @@ func createAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta i
 	}
 	key := ruleKeyFromGroup(group)
 
-	if err = client.SetAlertRuleGroup(group); err != nil {
+	if err := validateRuleNotificationSettings(client, group); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = setAlertRuleGroup(oapiClient, group); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -223,13 +279,17 @@ func createAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta i
 
 func updateAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, _ := ClientFromExistingOrgResource(meta, data.Id())
+	oapiClient, _, _ := OAPIClientFromExistingOrgResource(meta, data.Id())
 
 	group, err := unpackRuleGroup(data)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err = client.SetAlertRuleGroup(group); err != nil {
+	if err := validateRuleNotificationSettings(client, group); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = setAlertRuleGroup(oapiClient, group); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -268,7 +328,79 @@ func diffSuppressJSON(k, oldValue, newValue string, data *schema.ResourceData) b
 	return reflect.DeepEqual(o, n)
 }
 
-func packRuleGroup(g gapi.RuleGroup, data *schema.ResourceData) error {
+func diffSuppressDuration(k, oldValue, newValue string, data *schema.ResourceData) bool {
+	oldDuration, _ := promModel.ParseDuration(oldValue)
+	newDuration, _ := promModel.ParseDuration(newValue)
+	return oldDuration == newDuration
+}
+
+// ruleGroup and alertRule extend the API client's types with the notification settings of rules (simplified routing),
+// which the client doesn't support yet. Rule groups are read and written with `oapiRequest` for that reason.
+type ruleGroup struct {
+	Title     string      `json:"title"`
+	FolderUID string      `json:"folderUid"`
+	Interval  int64       `json:"interval"`
+	Rules     []alertRule `json:"rules"`
+}
+
+type alertRule struct {
+	gapi.AlertRule
+	NotificationSettings *alertRuleNotificationSettings `json:"notification_settings,omitempty"`
+}
+
+type alertRuleNotificationSettings struct {
+	Receiver          string   `json:"receiver"`
+	GroupBy           []string `json:"group_by,omitempty"`
+	GroupWait         string   `json:"group_wait,omitempty"`
+	GroupInterval     string   `json:"group_interval,omitempty"`
+	RepeatInterval    string   `json:"repeat_interval,omitempty"`
+	MuteTimeIntervals []string `json:"mute_time_intervals,omitempty"`
+}
+
+func alertRuleGroupPath(key AlertRuleGroupKey) string {
+	return fmt.Sprintf("/v1/provisioning/folder/%s/rule-groups/%s", url.PathEscape(key.FolderUID), url.PathEscape(key.Name))
+}
+
+func getAlertRuleGroup(client *goapi.GrafanaHTTPAPI, key AlertRuleGroupKey) (ruleGroup, error) {
+	var group ruleGroup
+	err := oapiRequest(client, http.MethodGet, alertRuleGroupPath(key), nil, nil, &group)
+	return group, err
+}
+
+func setAlertRuleGroup(client *goapi.GrafanaHTTPAPI, group ruleGroup) error {
+	return oapiRequest(client, http.MethodPut, alertRuleGroupPath(ruleKeyFromGroup(group)), nil, group, nil)
+}
+
+// validateRuleNotificationSettings checks that the contact points and mute timings referenced by the rules exist.
+// Grafana rejects the group otherwise, but its error doesn't tell which rule or reference is wrong.
+func validateRuleNotificationSettings(client *gapi.Client, group ruleGroup) error {
+	for _, r := range group.Rules {
+		settings := r.NotificationSettings
+		if settings == nil {
+			continue
+		}
+
+		contactPoints, err := client.ContactPointsByName(settings.Receiver)
+		if err != nil {
+			return err
+		}
+		if len(contactPoints) == 0 {
+			return fmt.Errorf("rule %q: contact point %q does not exist", r.Title, settings.Receiver)
+		}
+
+		for _, name := range settings.MuteTimeIntervals {
+			if _, err := client.MuteTiming(name); err != nil {
+				if common.IsNotFoundError(err) {
+					return fmt.Errorf("rule %q: mute timing %q does not exist", r.Title, name)
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func packRuleGroup(g ruleGroup, data *schema.ResourceData) error {
 	data.Set("name", g.Title)
 	data.Set("folder_uid", g.FolderUID)
 	data.Set("interval_seconds", g.Interval)
@@ -285,7 +417,7 @@ func packRuleGroup(g gapi.RuleGroup, data *schema.ResourceData) error {
 	return nil
 }
 
-func unpackRuleGroup(data *schema.ResourceData) (gapi.RuleGroup, error) {
+func unpackRuleGroup(data *schema.ResourceData) (ruleGroup, error) {
 	group := data.Get("name").(string)
 	folder := data.Get("folder_uid").(string)
 	interval := data.Get("interval_seconds").(int)
@@ -294,19 +426,19 @@ func unpackRuleGroup(data *schema.ResourceData) (gapi.RuleGroup, error) {
 	// org_id is a string to properly support referencing between resources. However, the API expects an int64.
 	orgID, err := strconv.ParseInt(data.Get("org_id").(string), 10, 64)
 	if err != nil {
-		return gapi.RuleGroup{}, err
+		return ruleGroup{}, err
 	}
 
-	rules := make([]gapi.AlertRule, 0, len(packedRules))
+	rules := make([]alertRule, 0, len(packedRules))
 	for i := range packedRules {
 		rule, err := unpackAlertRule(packedRules[i], group, folder, orgID)
 		if err != nil {
-			return gapi.RuleGroup{}, err
+			return ruleGroup{}, err
 		}
 		rules = append(rules, rule)
 	}
 
-	return gapi.RuleGroup{
+	return ruleGroup{
 		Title:     group,
 		FolderUID: folder,
 		Interval:  int64(interval),
@@ -314,7 +446,7 @@ func unpackRuleGroup(data *schema.ResourceData) (gapi.RuleGroup, error) {
 	}, nil
 }
 
-func packAlertRule(r gapi.AlertRule) (interface{}, error) {
+func packAlertRule(r alertRule) (interface{}, error) {
 	data, err := packRuleData(r.Data)
 	if err != nil {
 		return nil, err
@@ -331,17 +463,20 @@ func packAlertRule(r gapi.AlertRule) (interface{}, error) {
 		"data":           data,
 		"is_paused":      r.IsPaused,
 	}
+	if ns := packNotificationSettings(r.NotificationSettings); ns != nil {
+		json["notification_settings"] = ns
+	}
 	return json, nil
 }
 
-func unpackAlertRule(raw interface{}, groupName string, folderUID string, orgID int64) (gapi.AlertRule, error) {
+func unpackAlertRule(raw interface{}, groupName string, folderUID string, orgID int64) (alertRule, error) {
 	json := raw.(map[string]interface{})
 	data, err := unpackRuleData(json["data"])
 	if err != nil {
-		return gapi.AlertRule{}, err
+		return alertRule{}, err
 	}
 
-	return gapi.AlertRule{
+	rule := gapi.AlertRule{
 		UID:          json["uid"].(string),
 		Title:        json["name"].(string),
 		FolderUID:    folderUID,
@@ -355,9 +490,46 @@ func unpackAlertRule(raw interface{}, groupName string, folderUID string, orgID
 		Labels:       unpackMap(json["labels"]),
 		Annotations:  unpackMap(json["annotations"]),
 		IsPaused:     json["is_paused"].(bool),
+	}
+
+	return alertRule{
+		AlertRule:            rule,
+		NotificationSettings: unpackNotificationSettings(json["notification_settings"]),
 	}, nil
 }
 
+func packNotificationSettings(settings *alertRuleNotificationSettings) []interface{} {
+	if settings == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"contact_point":   settings.Receiver,
+			"group_by":        common.StringSliceToList(settings.GroupBy),
+			"group_wait":      settings.GroupWait,
+			"group_interval":  settings.GroupInterval,
+			"repeat_interval": settings.RepeatInterval,
+			"mute_timings":    common.StringSliceToList(settings.MuteTimeIntervals),
+		},
+	}
+}
+
+func unpackNotificationSettings(raw interface{}) *alertRuleNotificationSettings {
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	json := list[0].(map[string]interface{})
+	return &alertRuleNotificationSettings{
+		Receiver:          json["contact_point"].(string),
+		GroupBy:           common.ListToStringSlice(json["group_by"].([]interface{})),
+		GroupWait:         json["group_wait"].(string),
+		GroupInterval:     json["group_interval"].(string),
+		RepeatInterval:    json["repeat_interval"].(string),
+		MuteTimeIntervals: common.ListToStringSlice(json["mute_timings"].([]interface{})),
+	}
+}
+
 func packRuleData(queries []*gapi.AlertQuery) (interface{}, error) {
 	result := []interface{}{}
 	for i := range queries {
@@ -471,7 +643,7 @@ type AlertRuleGroupKey struct {
 	Name      string
 }
 
-func ruleKeyFromGroup(g gapi.RuleGroup) AlertRuleGroupKey {
+func ruleKeyFromGroup(g ruleGroup) AlertRuleGroupKey {
 	return AlertRuleGroupKey{
 		FolderUID: g.FolderUID,
 		Name:      g.Title,
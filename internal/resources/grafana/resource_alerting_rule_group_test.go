@@ -2,6 +2,7 @@ package grafana_test
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -261,6 +262,50 @@ func TestAccAlertRule_inOrg(t *testing.T) {
 	})
 }
 
+func TestAccAlertRule_NotificationSettings(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=10.4.0")
+
+	var group gapi.RuleGroup
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		// Implicitly tests deletion.
+		CheckDestroy: testAlertRuleCheckDestroy(&group),
+		Steps: []resource.TestStep{
+			// Test creation.
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_rule_group/_acc_notification_settings.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testRuleGroupCheckExists("grafana_rule_group.my_alert_rule", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.#", "1"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.notification_settings.#", "1"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.notification_settings.0.contact_point", "My Rule Contact Point"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.notification_settings.0.group_by.#", "3"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.notification_settings.0.group_by.2", "team"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.notification_settings.0.group_wait", "45s"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.notification_settings.0.group_interval", "6m"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.notification_settings.0.repeat_interval", "3h"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.notification_settings.0.mute_timings.#", "1"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.notification_settings.0.mute_timings.0", "My Rule Mute Timing"),
+				),
+			},
+			// Test import.
+			{
+				ResourceName:      "grafana_rule_group.my_alert_rule",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test that a missing contact point is reported.
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_rule_group/_acc_notification_settings.tf", map[string]string{
+					"grafana_contact_point.rule_contact_point.name": `"does-not-exist"`,
+				}),
+				ExpectError: regexp.MustCompile(`rule "My Alert Rule 1": contact point "does-not-exist" does not exist`),
+			},
+		},
+	})
+}
+
 func testRuleGroupCheckExists(rname string, g *gapi.RuleGroup) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		resource, ok := s.RootModule().Resources[rname]
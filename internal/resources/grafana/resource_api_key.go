@@ -2,9 +2,15 @@ package grafana
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"strconv"
+	"time"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
+	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,11 +25,16 @@ Manages Grafana API Keys.
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/auth/)
 
 !> Deprecated: please use ` + "`grafana_service_account`" + ` and ` + "`grafana_service_account_token`" + ` instead, see [Migrate API keys to Grafana service accounts using Terraform](https://grafana.com/docs/grafana/latest/administration/api-keys/#migrate-api-keys-to-grafana-service-accounts-using-terraform) for more information.
+
+Existing keys can be migrated in place with ` + "`migrate_to_service_account`" + `: the key becomes the token of a new service account, and keeps working.
+The ID of the service account is then set in ` + "`service_account_id`" + `, so that it can be imported in a ` + "`grafana_service_account`" + ` resource.
 `,
 
 		CreateContext:      resourceAPIKeyCreate,
 		ReadContext:        resourceAPIKeyRead,
+		UpdateContext:      resourceAPIKeyUpdate,
 		DeleteContext:      resourceAPIKeyDelete,
+		CustomizeDiff:      diffAPIKeyMigration,
 		DeprecationMessage: "Use `grafana_service_account` together with `grafana_service_account_token` instead, see https://grafana.com/docs/grafana/next/administration/api-keys/#migrate-api-keys-to-grafana-service-accounts-using-terraform",
 
 		Schema: map[string]*schema.Schema{
@@ -57,6 +68,16 @@ Manages Grafana API Keys.
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"migrate_to_service_account": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Convert the API key into a service account token. The key stays valid. This can't be undone.",
+			},
+			"service_account_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the service account that the key was migrated to.",
+			},
 		},
 	}
 }
@@ -77,11 +98,30 @@ func resourceAPIKeyCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	d.SetId(MakeOrgResourceID(orgID, response.ID))
 	d.Set("key", response.Key)
 
+	if d.Get("migrate_to_service_account").(bool) {
+		if err := migrateAPIKey(m, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// Fill the true resource's state after a create by performing a read
 	return resourceAPIKeyRead(ctx, d, m)
 }
 
+func resourceAPIKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("migrate_to_service_account") && d.Get("migrate_to_service_account").(bool) {
+		if err := migrateAPIKey(m, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceAPIKeyRead(ctx, d, m)
+}
+
 func resourceAPIKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("service_account_id").(string) != "" {
+		return readMigratedAPIKey(m, d)
+	}
+
 	c, orgID, idStr := ClientFromExistingOrgResource(m, d.Id())
 
 	response, err := c.GetAPIKeys(true)
@@ -115,6 +155,20 @@ func resourceAPIKeyRead(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceAPIKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if saIDStr := d.Get("service_account_id").(string); saIDStr != "" {
+		// The service account is left in place, it may have been imported in a grafana_service_account resource
+		client, _, idStr := OAPIClientFromExistingOrgResource(m, d.Id())
+		id, _ := strconv.ParseInt(idStr, 10, 64)
+		_, saIDStr = SplitOrgResourceID(saIDStr)
+		saID, _ := strconv.ParseInt(saIDStr, 10, 64)
+		params := service_accounts.NewDeleteTokenParams().WithServiceAccountID(saID).WithTokenID(id)
+		_, err := client.ServiceAccounts.DeleteToken(params, nil)
+		if err != nil && !common.IsOAPINotFoundError(err) {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
 	c, _, idStr := ClientFromExistingOrgResource(m, d.Id())
 	id, err := strconv.ParseInt(idStr, 10, 32)
 	if err != nil {
@@ -128,3 +182,123 @@ func resourceAPIKeyDelete(ctx context.Context, d *schema.ResourceData, m interfa
 
 	return nil
 }
+
+// diffAPIKeyMigration plans the migration of the API key, and prevents turning off `migrate_to_service_account`, since migrations can't be reverted.
+func diffAPIKeyMigration(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	old, new := d.GetChange("migrate_to_service_account")
+	switch {
+	case old.(bool) && !new.(bool):
+		return fmt.Errorf("the API key has been migrated to a service account, which can't be undone: migrate_to_service_account can't be set to false")
+	case !old.(bool) && new.(bool):
+		return d.SetNewComputed("service_account_id")
+	}
+	return nil
+}
+
+// migrateAPIKey converts the API key into a token of a new service account, and sets the ID of the service account.
+func migrateAPIKey(meta interface{}, d *schema.ResourceData) error {
+	client, orgID, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	if err := oapiRequest(client, "POST", "/serviceaccounts/migrate/"+idStr, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to migrate API key %s to a service account: %w", idStr, err)
+	}
+
+	// The migration doesn't return the service account. The token keeps the ID of the key, and the service account is named after it.
+	sa, err := findMigratedServiceAccount(client, orgID, id, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+	if sa == nil {
+		return fmt.Errorf("API key %s was migrated, but its service account can't be found", idStr)
+	}
+	return d.Set("service_account_id", MakeOrgResourceID(orgID, sa.ID))
+}
+
+// readMigratedAPIKey reads an API key that was migrated to a service account token.
+func readMigratedAPIKey(meta interface{}, d *schema.ResourceData) diag.Diagnostics {
+	client, orgID, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, saIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
+	saID, err := strconv.ParseInt(saIDStr, 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sa, err := client.ServiceAccounts.RetrieveServiceAccount(service_accounts.NewRetrieveServiceAccountParams().WithServiceAccountID(saID), nil)
+	if common.IsOAPINotFoundError(err) {
+		log.Printf("[WARN] removing API key %s from state because its service account no longer exists in grafana", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	token, err := findServiceAccountToken(client, saID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if token == nil {
+		log.Printf("[WARN] removing API key %s from state because its service account token no longer exists in grafana", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, id))
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set("name", token.Name)
+	d.Set("role", sa.Payload.Role)
+	if expiration := time.Time(token.Expiration); !expiration.IsZero() {
+		d.Set("expiration", expiration.String())
+	}
+	d.Set("service_account_id", MakeOrgResourceID(orgID, saID))
+
+	return nil
+}
+
+// findMigratedServiceAccount returns the service account created by the migration of an API key, which has the key as its token.
+// Grafana names the service account after the org ID and the name of the key.
+func findMigratedServiceAccount(client *goapi.GrafanaHTTPAPI, orgID, tokenID int64, keyName string) (*models.ServiceAccountDTO, error) {
+	name := fmt.Sprintf("sa-autogen-%d-%s", orgID, keyName)
+	params := service_accounts.NewSearchOrgServiceAccountsWithPagingParams().WithQuery(&name)
+	resp, err := client.ServiceAccounts.SearchOrgServiceAccountsWithPaging(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, sa := range resp.Payload.ServiceAccounts {
+		if sa.Name != name {
+			continue
+		}
+		token, err := findServiceAccountToken(client, sa.ID, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		if token != nil {
+			return sa, nil
+		}
+	}
+	return nil, nil
+}
+
+func findServiceAccountToken(client *goapi.GrafanaHTTPAPI, saID, tokenID int64) (*models.TokenDTO, error) {
+	// The OpenAPI client decodes the list of tokens as a single token
+	var tokens []*models.TokenDTO
+	if err := oapiRequest(client, "GET", fmt.Sprintf("/serviceaccounts/%d/tokens", saID), nil, nil, &tokens); err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		if token.ID == tokenID {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
@@ -3,7 +3,9 @@ package grafana_test
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
@@ -80,6 +82,64 @@ func TestAccGrafanaAuthKey_inOrg(t *testing.T) {
 	})
 }
 
+func TestAccGrafanaAuthKey_migrate(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
+
+	testName := acctest.RandString(10)
+	config := testAccGrafanaAuthKeyConfig(testName, "Editor", 0, false)
+	migratedConfig := strings.Replace(config, `role = "Editor"`, `role = "Editor"
+		migrate_to_service_account = true`, 1)
+	var id, key string
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccGrafanaAuthKeyCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccGrafanaAuthKeyCheckExists,
+					resource.TestCheckResourceAttr("grafana_api_key.foo", "service_account_id", ""),
+					resource.TestCheckResourceAttrWith("grafana_api_key.foo", "id", func(value string) error {
+						id = value
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("grafana_api_key.foo", "key", func(value string) error {
+						key = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: migratedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					// The key is now a service account token, with the same ID and secret
+					testAccGrafanaAuthKeyCheckDestroy,
+					resource.TestMatchResourceAttr("grafana_api_key.foo", "service_account_id", defaultOrgIDRegexp),
+					resource.TestCheckResourceAttr("grafana_api_key.foo", "name", testName),
+					resource.TestCheckResourceAttr("grafana_api_key.foo", "role", "Editor"),
+					resource.TestCheckResourceAttrWith("grafana_api_key.foo", "id", func(value string) error {
+						if value != id {
+							return fmt.Errorf("expected the ID to be kept, got %s instead of %s", value, id)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("grafana_api_key.foo", "key", func(value string) error {
+						if value != key {
+							return errors.New("expected the key to be kept")
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("migrate_to_service_account can't be set to false"),
+			},
+		},
+	})
+}
+
 func testAccGrafanaAuthKeyCheckExists(s *terraform.State) error {
 	return testAccGrafanaAuthKeyCheckExistsBool(s, true)
 }
@@ -0,0 +1,317 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// basicRoleUIDs maps the basic roles to the UIDs of their RBAC roles.
+var basicRoleUIDs = map[string]string{
+	"Viewer":        "basic_viewer",
+	"Editor":        "basic_editor",
+	"Admin":         "basic_admin",
+	"Grafana Admin": "basic_grafana_admin",
+}
+
+func ResourceBasicRolePermissions() *schema.Resource {
+	permissionSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"action": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Specific action (for example: `datasources:explore`)",
+			},
+			"scope": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Scope to restrict the action to a set of resources (for example: `datasources:*`)",
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Description: `
+Manages the permissions that are added to, or removed from, a basic role: ` + "`Viewer`, `Editor`, `Admin` or `Grafana Admin`" + `.
+
+Only the configured permissions are managed: the other permissions of the basic role, including the ones added by Grafana upgrades, are left as they are.
+The permissions that the resource actually grants or revokes (the basic role may already have an added permission, or not have a removed one)
+are recorded in ` + "`granted_permissions` and `revoked_permissions`" + `. Only these are undone when the resource is deleted,
+or when they are no longer in ` + "`added_permissions` and `removed_permissions`" + `.
+Managed permissions that are revoked or granted again outside of Terraform, for example in the Grafana UI, show up as changes to ` + "`added_permissions` and `removed_permissions`" + `.
+An imported resource doesn't manage any permission until it is applied.
+
+**Note:** This resource is available only with Grafana Enterprise 9.+.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/roles-and-permissions/access-control/manage-rbac-roles/#update-basic-role-permissions)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/access_control/#update-a-role)
+`,
+		CreateContext: CreateBasicRolePermissions,
+		ReadContext:   ReadBasicRolePermissions,
+		UpdateContext: UpdateBasicRolePermissions,
+		DeleteContext: DeleteBasicRolePermissions,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: diffBasicRolePermissions,
+
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Viewer", "Editor", "Admin", "Grafana Admin"}, false),
+				Description:  "The basic role. Options: `Viewer`, `Editor`, `Admin` or `Grafana Admin`.",
+			},
+			"added_permissions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Permissions granted to the basic role.",
+				Elem:        permissionSchema,
+			},
+			"removed_permissions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Permissions revoked from the basic role.",
+				Elem:        permissionSchema,
+			},
+			"granted_permissions": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Added permissions that the basic role didn't have. They are revoked when the resource is deleted.",
+				Elem:        permissionSchema,
+			},
+			"revoked_permissions": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Removed permissions that the basic role had. They are granted again when the resource is deleted.",
+				Elem:        permissionSchema,
+			},
+		},
+	}
+}
+
+func CreateBasicRolePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaAPI
+	uid := basicRoleUIDs[d.Get("role").(string)]
+
+	role, err := client.GetRole(uid)
+	if err != nil {
+		return diag.Errorf("failed to get basic role %s: %s", uid, err)
+	}
+	d.SetId(uid)
+
+	if err := applyBasicRolePermissions(d, client, role); err != nil {
+		return diag.FromErr(err)
+	}
+	return ReadBasicRolePermissions(ctx, d, meta)
+}
+
+func ReadBasicRolePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaAPI
+	role, err := client.GetRole(d.Id())
+	if err, shouldReturn := common.CheckReadError("basic role", d, err); shouldReturn {
+		return err
+	}
+
+	// Managed permissions that were revoked or granted again outside of Terraform are dropped, so that they are applied again
+	current := permissionsByKey(role.Permissions)
+	added := map[string]gapi.Permission{}
+	for key, p := range permissionsByKey(permissionsFromSet(d.Get("added_permissions").(*schema.Set))) {
+		if _, ok := current[key]; ok {
+			added[key] = p
+		}
+	}
+	removed := map[string]gapi.Permission{}
+	for key, p := range permissionsByKey(permissionsFromSet(d.Get("removed_permissions").(*schema.Set))) {
+		if _, ok := current[key]; !ok {
+			removed[key] = p
+		}
+	}
+
+	for name, uid := range basicRoleUIDs {
+		if uid == d.Id() {
+			d.Set("role", name)
+		}
+	}
+	if err := d.Set("added_permissions", permissionsToSet(sortedPermissions(added))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("removed_permissions", permissionsToSet(sortedPermissions(removed))); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func UpdateBasicRolePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaAPI
+	role, err := client.GetRole(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := applyBasicRolePermissions(d, client, role); err != nil {
+		return diag.FromErr(err)
+	}
+	return ReadBasicRolePermissions(ctx, d, meta)
+}
+
+func DeleteBasicRolePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaAPI
+	role, err := client.GetRole(d.Id())
+	if diags, shouldReturn := common.CheckReadError("basic role", d, err); shouldReturn {
+		return diags
+	}
+	// Undo the changes made by the resource, and leave the other permissions as they are
+	added := permissionsFromSet(d.Get("revoked_permissions").(*schema.Set))
+	removed := permissionsFromSet(d.Get("granted_permissions").(*schema.Set))
+	return diag.FromErr(updateBasicRolePermissions(client, role, changedPermissions(role.Permissions, added, removed)))
+}
+
+// applyBasicRolePermissions grants the added permissions and revokes the removed permissions, and records the ones that actually changed.
+// The recorded changes that are no longer configured are undone.
+func applyBasicRolePermissions(d *schema.ResourceData, client *gapi.Client, role *gapi.Role) error {
+	current := permissionsByKey(role.Permissions)
+	granted := permissionsByKey(permissionsFromSet(d.Get("granted_permissions").(*schema.Set)))
+	revoked := permissionsByKey(permissionsFromSet(d.Get("revoked_permissions").(*schema.Set)))
+	oldAdded, newAdded := d.GetChange("added_permissions")
+	oldRemoved, newRemoved := d.GetChange("removed_permissions")
+
+	for key := range permissionsByKey(permissionsFromSet(oldAdded.(*schema.Set).Difference(newAdded.(*schema.Set)))) {
+		if _, ok := granted[key]; ok {
+			delete(current, key)
+			delete(granted, key)
+		}
+	}
+	for key, p := range permissionsByKey(permissionsFromSet(oldRemoved.(*schema.Set).Difference(newRemoved.(*schema.Set)))) {
+		if _, ok := revoked[key]; ok {
+			current[key] = p
+			delete(revoked, key)
+		}
+	}
+	for key, p := range permissionsByKey(permissionsFromSet(newAdded.(*schema.Set))) {
+		if _, ok := current[key]; !ok {
+			current[key] = p
+			granted[key] = p
+		}
+	}
+	for key, p := range permissionsByKey(permissionsFromSet(newRemoved.(*schema.Set))) {
+		if _, ok := current[key]; ok {
+			delete(current, key)
+			revoked[key] = p
+		}
+	}
+
+	if err := updateBasicRolePermissions(client, role, sortedPermissions(current)); err != nil {
+		return err
+	}
+	if err := d.Set("granted_permissions", permissionsToSet(sortedPermissions(granted))); err != nil {
+		return err
+	}
+	return d.Set("revoked_permissions", permissionsToSet(sortedPermissions(revoked)))
+}
+
+// diffBasicRolePermissions plans changes to the recorded permissions when the added or removed permissions change.
+func diffBasicRolePermissions(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("added_permissions", "removed_permissions") {
+		return nil
+	}
+	if err := d.SetNewComputed("granted_permissions"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("revoked_permissions")
+}
+
+// changedPermissions returns the current permissions, without the removed permissions, and with the added permissions.
+func changedPermissions(current, added, removed []gapi.Permission) []gapi.Permission {
+	permissions := permissionsByKey(current)
+	for key := range permissionsByKey(removed) {
+		delete(permissions, key)
+	}
+	for key, p := range permissionsByKey(added) {
+		permissions[key] = p
+	}
+	return sortedPermissions(permissions)
+}
+
+// updateBasicRolePermissions replaces the permissions of a basic role. Roles are only updated when their version increases.
+func updateBasicRolePermissions(client *gapi.Client, role *gapi.Role, permissions []gapi.Permission) error {
+	if samePermissions(role.Permissions, permissions) {
+		return nil
+	}
+	updated := *role
+	updated.Version++
+	updated.Global = true
+	updated.Permissions = permissions
+	if err := client.UpdateRole(updated); err != nil {
+		return fmt.Errorf("failed to update the permissions of basic role %s: %w", role.UID, err)
+	}
+	return nil
+}
+
+func permissionKey(p gapi.Permission) string {
+	return p.Action + "|" + p.Scope
+}
+
+func permissionsByKey(permissions []gapi.Permission) map[string]gapi.Permission {
+	byKey := map[string]gapi.Permission{}
+	for _, p := range permissions {
+		byKey[permissionKey(p)] = p
+	}
+	return byKey
+}
+
+func sortedPermissions(byKey map[string]gapi.Permission) []gapi.Permission {
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	permissions := make([]gapi.Permission, 0, len(keys))
+	for _, key := range keys {
+		permissions = append(permissions, byKey[key])
+	}
+	return permissions
+}
+
+func samePermissions(a, b []gapi.Permission) bool {
+	aKeys, bKeys := permissionsByKey(a), permissionsByKey(b)
+	if len(aKeys) != len(bKeys) {
+		return false
+	}
+	for key := range aKeys {
+		if _, ok := bKeys[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func permissionsFromSet(set *schema.Set) []gapi.Permission {
+	permissions := make([]gapi.Permission, 0, set.Len())
+	for _, v := range set.List() {
+		p := v.(map[string]interface{})
+		permissions = append(permissions, gapi.Permission{
+			Action: p["action"].(string),
+			Scope:  p["scope"].(string),
+		})
+	}
+	return permissions
+}
+
+func permissionsToSet(permissions []gapi.Permission) []interface{} {
+	set := make([]interface{}, 0, len(permissions))
+	for _, p := range permissions {
+		set = append(set, map[string]interface{}{
+			"action": p.Action,
+			"scope":  p.Scope,
+		})
+	}
+	return set
+}
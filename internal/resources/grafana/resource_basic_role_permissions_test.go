@@ -0,0 +1,144 @@
+package grafana_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBasicRolePermissions_basic(t *testing.T) {
+	testutils.CheckEnterpriseTestsEnabled(t, ">=9.0.0")
+
+	explore := gapi.Permission{Action: "datasources:explore"}
+	readReports := gapi.Permission{Action: "reports:read", Scope: "reports:*"}
+	createReports := gapi.Permission{Action: "reports:create"}
+	writeReports := gapi.Permission{Action: "reports:write", Scope: "reports:*"}
+
+	// createReports is granted before the resource is created, and also added by it: it must be kept when the resource is deleted.
+	// writeReports is granted outside of Terraform, and must be left as it is.
+	config := strings.Replace(testutils.TestAccExample(t, "resources/grafana_basic_role_permissions/resource.tf"), `role = "Viewer"`, `role = "Viewer"
+
+  added_permissions {
+    action = "reports:create"
+  }`, 1)
+	t.Cleanup(func() {
+		updateBasicRolePermissionsOutside(t, "basic_viewer", nil, []gapi.Permission{createReports, writeReports})
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      basicRolePermissionsCheck("basic_viewer", []gapi.Permission{explore, createReports, writeReports}, []gapi.Permission{readReports}),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					updateBasicRolePermissionsOutside(t, "basic_viewer", []gapi.Permission{createReports}, nil)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					basicRolePermissionsCheck("basic_viewer", []gapi.Permission{readReports, createReports}, []gapi.Permission{explore}),
+					resource.TestCheckResourceAttr("grafana_basic_role_permissions.viewer", "id", "basic_viewer"),
+					resource.TestCheckResourceAttr("grafana_basic_role_permissions.viewer", "removed_permissions.#", "1"),
+					resource.TestCheckResourceAttr("grafana_basic_role_permissions.viewer", "added_permissions.#", "2"),
+					resource.TestCheckResourceAttr("grafana_basic_role_permissions.viewer", "revoked_permissions.#", "1"),
+					resource.TestCheckResourceAttr("grafana_basic_role_permissions.viewer", "granted_permissions.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("grafana_basic_role_permissions.viewer", "granted_permissions.*", map[string]string{
+						"action": readReports.Action,
+						"scope":  readReports.Scope,
+					}),
+				),
+			},
+			{
+				// Permissions that aren't managed are ignored
+				PreConfig: func() {
+					updateBasicRolePermissionsOutside(t, "basic_viewer", []gapi.Permission{writeReports}, nil)
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				// Managed permissions changed outside of Terraform are drift
+				PreConfig: func() {
+					updateBasicRolePermissionsOutside(t, "basic_viewer", []gapi.Permission{explore}, nil)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  basicRolePermissionsCheck("basic_viewer", []gapi.Permission{readReports, createReports, writeReports}, []gapi.Permission{explore}),
+			},
+			{
+				// An imported resource doesn't manage any permission
+				ResourceName:            "grafana_basic_role_permissions.viewer",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"added_permissions", "removed_permissions", "granted_permissions", "revoked_permissions"},
+			},
+		},
+	})
+}
+
+// updateBasicRolePermissionsOutside grants and revokes permissions of a basic role, as it would be done outside of Terraform.
+func updateBasicRolePermissionsOutside(t *testing.T, uid string, granted, revoked []gapi.Permission) {
+	t.Helper()
+	client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+	role, err := client.GetRole(uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var permissions []gapi.Permission
+	for _, p := range role.Permissions {
+		keep := true
+		for _, r := range revoked {
+			if p.Action == r.Action && p.Scope == r.Scope {
+				keep = false
+			}
+		}
+		if keep {
+			permissions = append(permissions, p)
+		}
+	}
+	role.Version++
+	role.Global = true
+	role.Permissions = append(permissions, granted...)
+	if err := client.UpdateRole(*role); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// basicRolePermissionsCheck checks that a basic role has some permissions, and doesn't have others.
+func basicRolePermissionsCheck(uid string, granted, notGranted []gapi.Permission) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+		role, err := client.GetRole(uid)
+		if err != nil {
+			return err
+		}
+		has := func(p gapi.Permission) bool {
+			for _, rp := range role.Permissions {
+				if rp.Action == p.Action && rp.Scope == p.Scope {
+					return true
+				}
+			}
+			return false
+		}
+		for _, p := range granted {
+			if !has(p) {
+				return fmt.Errorf("basic role %s doesn't have permission %s on %q", uid, p.Action, p.Scope)
+			}
+		}
+		for _, p := range notGranted {
+			if has(p) {
+				return fmt.Errorf("basic role %s has permission %s on %q", uid, p.Action, p.Scope)
+			}
+		}
+		return nil
+	}
+}
@@ -5,19 +5,23 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboard_versions"
+	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/internal/common"
 )
 
-var (
-	StoreDashboardSHA256 bool
-)
-
 func ResourceDashboard() *schema.Resource {
 	return &schema.Resource{
 
@@ -32,6 +36,10 @@ Manages Grafana dashboards.
 		ReadContext:   ReadDashboard,
 		UpdateContext: UpdateDashboard,
 		DeleteContext: DeleteDashboard,
+		CustomizeDiff: customdiff.All(
+			validateDashboardDiff,
+			dashboardChangeSummaryDiff,
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -72,11 +80,34 @@ Manages Grafana dashboards.
 				},
 			},
 			"config_json": {
-				Type:         schema.TypeString,
-				Required:     true,
-				StateFunc:    NormalizeDashboardConfigJSON,
-				ValidateFunc: validateDashboardConfigJSON,
-				Description:  "The complete dashboard model JSON.",
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        NormalizeDashboardConfigJSON,
+				ValidateFunc:     validateDashboardConfigJSON,
+				DiffSuppressFunc: diffSuppressDashboardConfigJSON,
+				Description:      "The complete dashboard model JSON.",
+			},
+			"change_summary": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Description: "Summary of the changes to the dashboard model in `config_json`, computed when planning them: " +
+					"panels and variables added, removed or modified, along with the modified attributes. It holds the last applied changes otherwise.",
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+			"restore_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Set to a version number (see the `grafana_dashboard_versions` data source) to restore the dashboard to that version. " +
+					"Grafana saves the restored model as a new version and it is read back into `config_json`. " +
+					"Changes to `config_json` are ignored while this is set. " +
+					"To resume managing the dashboard from `config_json`, copy the restored model into it and remove this attribute.",
+			},
+			"store_sha256": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Set to true to store only the sha256sum of `config_json` in the state, instead of the complete dashboard model JSON. " +
+					"If not set, the provider's `store_dashboard_sha256` setting is used.",
 			},
 			"overwrite": {
 				Type:        schema.TypeBool,
@@ -88,6 +119,22 @@ Manages Grafana dashboards.
 				Optional:    true,
 				Description: "Set a commit message for the version history.",
 			},
+			"validation_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{dashboardValidationOff, dashboardValidationWarn, dashboardValidationError}, false),
+				Description: "Validation of `config_json` against the dashboard schema (schema version, panel ids, positions and types, template variables). " +
+					"Panel types and referenced data sources are also checked against the Grafana instance. " +
+					"With `warn`, issues are reported as warnings when the dashboard is saved. With `error`, they fail the plan. " +
+					"A `schemaVersion` newer than the latest one known to the provider is only reported as a warning. " +
+					"Can be `off`, `warn` or `error`. Defaults to `off`.",
+			},
+			"fail_on_external_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Set to true to fail updates if the dashboard was changed outside of Terraform (e.g. in the UI) since it was last read (when the plan was made), " +
+					"instead of overwriting these changes. The error lists the remote changes.",
+			},
 		},
 		SchemaVersion: 1, // The state upgrader was removed in v2. To upgrade, users can first upgrade to the last v1 release, apply, then upgrade to v2.
 	}
@@ -96,6 +143,10 @@ Manages Grafana dashboards.
 func CreateDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := ClientFromNewOrgResource(meta, d)
 
+	if d.Get("restore_version").(int) > 0 {
+		return diag.Errorf("`restore_version` can only be set on an existing dashboard")
+	}
+
 	dashboard, err := makeDashboard(d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -105,7 +156,7 @@ func CreateDashboard(ctx context.Context, d *schema.ResourceData, meta interface
 		return diag.FromErr(err)
 	}
 	d.SetId(MakeOrgResourceID(orgID, resp.UID))
-	return ReadDashboard(ctx, d, meta)
+	return append(dashboardValidationWarnings(d, meta), ReadDashboard(ctx, d, meta)...)
 }
 
 func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -160,6 +211,9 @@ func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}
 		}
 	}
 	configJSON = NormalizeDashboardConfigJSON(remoteDashJSON)
+	if storeSHA256(d, metaClient.StoreDashboardSHA256) {
+		configJSON = sha256JSON(configJSON)
+	}
 	d.Set("config_json", configJSON)
 
 	return nil
@@ -168,17 +222,210 @@ func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}
 func UpdateDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := ClientFromNewOrgResource(meta, d)
 
+	// The other attributes (store_sha256, validation_mode, fail_on_external_change) only change the state or how changes are checked.
+	// Saving the dashboard for them would create a new version and overwrite changes made in the UI.
+	if !d.HasChanges("config_json", "folder", "overwrite", "message", "restore_version") {
+		return ReadDashboard(ctx, d, meta)
+	}
+
+	if restoreVersion := d.Get("restore_version").(int); restoreVersion > 0 {
+		return restoreDashboard(ctx, d, meta, restoreVersion)
+	}
+
 	dashboard, err := makeDashboard(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	dashboard.Model["id"] = d.Get("dashboard_id").(int)
 	dashboard.Overwrite = true
+	failOnExternalChange := d.Get("fail_on_external_change").(bool)
+	if failOnExternalChange {
+		// Grafana rejects the save if the dashboard's version isn't the one we last read
+		dashboard.Model["version"] = d.Get("version").(int)
+		dashboard.Overwrite = false
+	}
 	resp, err := client.NewDashboard(dashboard)
+	if failOnExternalChange && err != nil && strings.Contains(err.Error(), "version-mismatch") {
+		return externalDashboardChangeDiags(d, meta, err)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(MakeOrgResourceID(orgID, resp.UID))
+	return append(dashboardValidationWarnings(d, meta), ReadDashboard(ctx, d, meta)...)
+}
+
+// validateDashboardDiff fails the plan if `validation_mode` is `error` and the configured dashboard model has issues.
+func validateDashboardDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("validation_mode").(string) != dashboardValidationError || !d.NewValueKnown("config_json") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("config_json", "validation_mode") {
+		return nil
+	}
+	issues, _, err := validateConfiguredDashboard(d, meta)
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("invalid dashboard model in `config_json`:\n  - %s", strings.Join(issues, "\n  - "))
+	}
+	return nil
+}
+
+// dashboardChangeSummaryDiff sets `change_summary` to the structural diff between the current and the configured dashboard models.
+// If only the hash of the current model is stored, the current model is read from Grafana.
+func dashboardChangeSummaryDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("config_json") || !d.NewValueKnown("config_json") {
+		return nil
+	}
+
+	oldJSON, _ := d.GetChange("config_json")
+	oldModelJSON := oldJSON.(string)
+	if common.SHA256Regexp.MatchString(oldModelJSON) {
+		client, _, uid := ClientFromExistingOrgResource(meta, d.Id())
+		dashboard, err := client.DashboardByUID(uid)
+		if err != nil {
+			// The dashboard may have been deleted. Its summary will be known after apply.
+			return d.SetNewComputed("change_summary")
+		}
+		oldModelJSON = NormalizeDashboardConfigJSON(dashboard.Model)
+	}
+	oldModel, err := UnmarshalDashboardConfigJSON(oldModelJSON)
+	if err != nil {
+		return d.SetNewComputed("change_summary")
+	}
+	newModel, err := UnmarshalDashboardConfigJSON(NormalizeDashboardConfigJSON(configuredModelJSON(d, "config_json")))
+	if err != nil {
+		return nil // Reported by the attribute's ValidateFunc
+	}
+	// Like in the state, the uid generated by Grafana isn't compared if it isn't configured
+	if _, ok := newModel["uid"].(string); !ok {
+		delete(oldModel, "uid")
+	}
+
+	return d.SetNew("change_summary", diffDashboardModels(oldModel, newModel))
+}
+
+// dashboardValidationWarnings reports the issues of the configured dashboard model as a warning, if `validation_mode` is `warn`.
+// Warnings that aren't issues, such as a newer schema version, are also reported with `error`.
+func dashboardValidationWarnings(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	mode := d.Get("validation_mode").(string)
+	if mode != dashboardValidationWarn && mode != dashboardValidationError {
+		return nil
+	}
+	issues, warnings, err := validateConfiguredDashboard(d, meta)
+	if err != nil {
+		return diag.Diagnostics{{Severity: diag.Warning, Summary: "failed to validate the dashboard", Detail: err.Error()}}
+	}
+	if mode == dashboardValidationWarn {
+		warnings = append(issues, warnings...)
+	}
+	if len(warnings) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "the dashboard model in `config_json` has issues",
+		Detail:   "- " + strings.Join(warnings, "\n- "),
+	}}
+}
+
+// validateConfiguredDashboard validates the configured dashboard model, against the instance of the resource's org.
+// Models that aren't valid JSON are left to the attribute's ValidateFunc.
+func validateConfiguredDashboard(d rawConfigGetter, meta interface{}) ([]string, []string, error) {
+	model, err := UnmarshalDashboardConfigJSON(configuredModelJSON(d, "config_json"))
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	client := meta.(*common.Client).GrafanaOAPI
+	if client != nil {
+		client = client.Clone()
+		if orgID, _ := strconv.ParseInt(d.Get("org_id").(string), 10, 64); orgID > 0 {
+			client = client.WithOrgID(orgID)
+		}
+	}
+	vc, err := newDashboardValidationContext(client)
+	if err != nil {
+		return nil, nil, err
+	}
+	issues, warnings := validateDashboardModel(model, vc)
+	return issues, warnings, nil
+}
+
+// externalDashboardChangeDiags describes the changes made to a dashboard since the version in the state,
+// for when an update is rejected because of a version mismatch.
+func externalDashboardChangeDiags(d *schema.ResourceData, meta interface{}, saveErr error) diag.Diagnostics {
+	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
+	version := int64(d.Get("version").(int))
+
+	summary := fmt.Sprintf("dashboard %s was changed outside of Terraform since version %d", uid, version)
+	detail := fmt.Sprintf("Grafana refused to save the dashboard: %s", saveErr)
+
+	oldResp, err := client.DashboardVersions.GetDashboardVersionByUID(dashboard_versions.NewGetDashboardVersionByUIDParams().WithUID(uid).WithDashboardVersionID(version), nil)
+	if err != nil {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: summary, Detail: detail}}
+	}
+	limit := int64(1)
+	latestResp, err := client.DashboardVersions.GetDashboardVersionsByUID(dashboard_versions.NewGetDashboardVersionsByUIDParams().WithUID(uid).WithLimit(&limit), nil)
+	if err != nil || len(latestResp.Payload) == 0 {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: summary, Detail: detail}}
+	}
+	latest := latestResp.Payload[0]
+	latestVersion, err := client.DashboardVersions.GetDashboardVersionByUID(dashboard_versions.NewGetDashboardVersionByUIDParams().WithUID(uid).WithDashboardVersionID(latest.Version), nil)
+	if err != nil {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: summary, Detail: detail}}
+	}
+
+	oldModel, _ := oldResp.Payload.Data.(map[string]interface{})
+	newModel, _ := latestVersion.Payload.Data.(map[string]interface{})
+	changes := diffDashboardModels(oldModel, newModel)
+	if len(changes) == 0 {
+		changes = []string{"(no changes to the dashboard model)"}
+	}
+
+	detail = fmt.Sprintf("The dashboard is now at version %d, saved by %s at %s", latest.Version, latest.CreatedBy, time.Time(latest.Created).Format(time.RFC3339))
+	if latest.Message != "" {
+		detail += fmt.Sprintf(" with message %q", latest.Message)
+	}
+	detail += ". Remote changes:\n\n  " + strings.Join(changes, "\n  ") +
+		"\n\nInclude these changes in `config_json` (or discard them by unsetting `fail_on_external_change`), then refresh and apply again."
+
+	return diag.Diagnostics{{Severity: diag.Error, Summary: summary, Detail: detail}}
+}
+
+// restoreDashboard restores the dashboard to the given version, if it changed.
+// The other attributes (folder, message) are then applied to the restored model rather than to the configured `config_json`.
+func restoreDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}, version int) diag.Diagnostics {
+	oapiClient, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
+	client, _, _ := ClientFromExistingOrgResource(meta, d.Id())
+
+	if d.HasChange("restore_version") {
+		params := dashboard_versions.NewRestoreDashboardVersionByUIDParams().
+			WithUID(uid).
+			WithBody(&models.RestoreDashboardVersionCommand{Version: int64(version)})
+		if _, err := oapiClient.DashboardVersions.RestoreDashboardVersionByUID(params, nil); err != nil {
+			return diag.Errorf("failed to restore version %d of dashboard %s: %s", version, uid, err)
+		}
+	}
+
+	if d.HasChanges("folder", "overwrite", "message") {
+		remote, err := client.DashboardByUID(uid)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		dashboard, err := makeDashboard(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		dashboard.Model = remote.Model
+		dashboard.Overwrite = true
+		if _, err := client.NewDashboard(dashboard); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return ReadDashboard(ctx, d, meta)
 }
 
@@ -188,19 +435,24 @@ func DeleteDashboard(ctx context.Context, d *schema.ResourceData, meta interface
 }
 
 func makeDashboard(d *schema.ResourceData) (gapi.Dashboard, error) {
+	return makeDashboardFromJSON(configuredModelJSON(d, "config_json"), d.Get("folder").(string), d.Get("overwrite").(bool), d.Get("message").(string))
+}
+
+// makeDashboardFromJSON builds the dashboard to save from its model JSON.
+// The folder may be given as a numeric ID or as a UID, optionally prefixed with the org ID.
+func makeDashboardFromJSON(configJSON, folder string, overwrite bool, message string) (gapi.Dashboard, error) {
 	dashboard := gapi.Dashboard{
-		Overwrite: d.Get("overwrite").(bool),
-		Message:   d.Get("message").(string),
+		Overwrite: overwrite,
+		Message:   message,
 	}
 
-	_, folderID := SplitOrgResourceID(d.Get("folder").(string))
+	_, folderID := SplitOrgResourceID(folder)
 	if folderInt, err := strconv.ParseInt(folderID, 10, 64); err == nil {
 		dashboard.FolderID = folderInt
 	} else {
 		dashboard.FolderUID = folderID
 	}
 
-	configJSON := d.Get("config_json").(string)
 	dashboardJSON, err := UnmarshalDashboardConfigJSON(configJSON)
 	if err != nil {
 		return dashboard, err
@@ -242,6 +494,8 @@ func validateDashboardConfigJSON(config interface{}, k string) ([]string, []erro
 //     creation. We cannot know this before creation and therefore it cannot
 //     be managed in code.
 //   - `version`: is incremented by Grafana each time a dashboard changes.
+//
+// Panels, including the ones nested in collapsed rows, are normalized with normalizeDashboardPanels.
 func NormalizeDashboardConfigJSON(config interface{}) string {
 	var dashboardJSON map[string]interface{}
 	switch c := config.(type) {
@@ -258,29 +512,143 @@ func NormalizeDashboardConfigJSON(config interface{}) string {
 	delete(dashboardJSON, "id")
 	delete(dashboardJSON, "version")
 
-	// similarly to uid removal above, remove any attributes panels[].libraryPanel.*
-	// from the dashboard JSON other than "name" or "uid".
-	// Grafana will populate all other libraryPanel attributes, so delete them to avoid diff.
 	if panels, ok := dashboardJSON["panels"].([]interface{}); ok {
-		for _, panel := range panels {
-			panelMap := panel.(map[string]interface{})
-			delete(panelMap, "id")
-			if libraryPanel, ok := panelMap["libraryPanel"].(map[string]interface{}); ok {
-				for k := range libraryPanel {
-					if k != "name" && k != "uid" {
-						delete(libraryPanel, k)
-					}
+		normalizeDashboardPanels(panels)
+	}
+
+	j, _ := json.Marshal(dashboardJSON)
+	return string(j)
+}
+
+// storeSHA256 tells whether only the sha256sum of the model JSON of a dashboard or library panel should be stored in the state.
+// The resource's `store_sha256` attribute takes precedence over the given default (the provider's setting).
+func storeSHA256(d *schema.ResourceData, defaultValue bool) bool {
+	if v, ok := d.GetOkExists("store_sha256"); ok { //nolint:staticcheck // GetOk can't tell false from unset
+		return v.(bool)
+	}
+	return defaultValue
+}
+
+// sha256JSON returns the hex-encoded sha256sum of a normalized model JSON.
+func sha256JSON(modelJSON string) string {
+	hash := sha256.Sum256([]byte(modelJSON))
+	return fmt.Sprintf("%x", hash[:])
+}
+
+// diffSuppressSHA256JSON is the DiffSuppressFunc of model JSON fields which may be stored as a sha256sum.
+// The new value is already normalized by the field's StateFunc, so its hash can be compared to the stored one.
+func diffSuppressSHA256JSON(k, old, new string, d *schema.ResourceData) bool {
+	return common.SHA256Regexp.MatchString(old) && sha256JSON(new) == old
+}
+
+// diffSuppressDashboardConfigJSON ignores changes to the dashboard model while it is pinned to a restored version.
+func diffSuppressDashboardConfigJSON(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("restore_version").(int) > 0 || diffSuppressSHA256JSON(k, old, new, d)
+}
+
+// rawConfigGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type rawConfigGetter interface {
+	GetRawConfig() cty.Value
+	Get(string) interface{}
+}
+
+// configuredModelJSON returns the model JSON from the configuration.
+// The state (and therefore `d.Get`) may only hold its sha256sum.
+func configuredModelJSON(d rawConfigGetter, key string) string {
+	if config := d.GetRawConfig(); config.IsKnown() && !config.IsNull() {
+		if v := config.GetAttr(key); v.IsKnown() && !v.IsNull() {
+			return v.AsString()
+		}
+	}
+	return d.Get(key).(string)
+}
+
+// normalizeDashboardPanels removes the panel attributes that are populated by Grafana, so that they don't cause diffs:
+//
+//   - `id`: assigned by Grafana when the dashboard is saved.
+//   - `libraryPanel.*`: all attributes other than "name" and "uid" are populated by Grafana.
+//   - `pluginVersion`: set by the frontend to the version of the panel plugin when the dashboard is saved from the UI.
+//   - `fieldConfig`: removed when it only holds the empty defaults the frontend adds to every panel.
+//
+// Panels are also sorted by their position (`gridPos.y`, then `gridPos.x`), which is the order Grafana saves them in.
+// Panels within collapsed rows (`panels[].panels[]`) are normalized the same way.
+func normalizeDashboardPanels(panels []interface{}) {
+	for _, panel := range panels {
+		panelMap, ok := panel.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		delete(panelMap, "id")
+		delete(panelMap, "pluginVersion")
+		if libraryPanel, ok := panelMap["libraryPanel"].(map[string]interface{}); ok {
+			for k := range libraryPanel {
+				if k != "name" && k != "uid" {
+					delete(libraryPanel, k)
 				}
 			}
 		}
+		if isDefaultPanelFieldConfig(panelMap["fieldConfig"]) {
+			delete(panelMap, "fieldConfig")
+		}
+		if rowPanels, ok := panelMap["panels"].([]interface{}); ok {
+			normalizeDashboardPanels(rowPanels)
+		}
 	}
 
-	j, _ := json.Marshal(dashboardJSON)
+	sortDashboardPanelsByPosition(panels)
+}
 
-	if StoreDashboardSHA256 {
-		configHash := sha256.Sum256(j)
-		return fmt.Sprintf("%x", configHash[:])
-	} else {
-		return string(j)
+func isDefaultPanelFieldConfig(fieldConfig interface{}) bool {
+	fieldConfigMap, ok := fieldConfig.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for k, v := range fieldConfigMap {
+		switch k {
+		case "defaults":
+			if defaults, ok := v.(map[string]interface{}); !ok || len(defaults) > 0 {
+				return false
+			}
+		case "overrides":
+			if overrides, ok := v.([]interface{}); !ok || len(overrides) > 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// sortDashboardPanelsByPosition sorts panels top to bottom, then left to right.
+// Panels are left untouched if any of them doesn't have a position.
+func sortDashboardPanelsByPosition(panels []interface{}) {
+	type positionedPanel struct {
+		x, y  float64
+		panel interface{}
+	}
+	positioned := make([]positionedPanel, 0, len(panels))
+	for _, panel := range panels {
+		panelMap, _ := panel.(map[string]interface{})
+		gridPos, ok := panelMap["gridPos"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		x, xOk := gridPos["x"].(float64)
+		y, yOk := gridPos["y"].(float64)
+		if !xOk || !yOk {
+			return
+		}
+		positioned = append(positioned, positionedPanel{x: x, y: y, panel: panel})
+	}
+
+	sort.SliceStable(positioned, func(i, j int) bool {
+		if positioned[i].y != positioned[j].y {
+			return positioned[i].y < positioned[j].y
+		}
+		return positioned[i].x < positioned[j].x
+	})
+	for i := range positioned {
+		panels[i] = positioned[i].panel
 	}
 }
@@ -0,0 +1,24 @@
+package grafana
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceDashboardPermissionItem() *schema.Resource {
+	return resourcePermissionItem(
+		permissionItemTarget{
+			resource:     "dashboards",
+			uidAttribute: "dashboard_uid",
+			description:  "The UID of the dashboard.",
+			permissions:  []string{"View", "Edit", "Admin"},
+		},
+		`
+Manages a single permission item for a dashboard: the permission of one user, team or basic role.
+Unlike `+"`grafana_dashboard_permission`"+`, it doesn't remove the other permissions of the dashboard, so several configurations can grant access to the same dashboard.
+Don't use it along with `+"`grafana_dashboard_permission`"+` for the same dashboard.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/roles-and-permissions/access-control/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard_permissions/)
+`,
+	)
+}
@@ -0,0 +1,48 @@
+package grafana_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDashboardPermissionItem_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.0.0")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_dashboard_permission_item/resource.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("grafana_dashboard_permission_item.on_role", "dashboard_uid", "grafana_dashboard.dashboard", "uid"),
+					resource.TestMatchResourceAttr("grafana_dashboard_permission_item.on_role", "id", regexp.MustCompile(`^1:[a-zA-Z0-9-_]+:role:Viewer$`)),
+					resource.TestCheckResourceAttr("grafana_dashboard_permission_item.on_role", "permission", "Edit"),
+					resource.TestMatchResourceAttr("grafana_dashboard_permission_item.on_team", "id", regexp.MustCompile(`^1:[a-zA-Z0-9-_]+:team:\d+$`)),
+					resource.TestCheckResourceAttrPair("grafana_dashboard_permission_item.on_team", "team_id", "grafana_team.team", "id"),
+					resource.TestCheckResourceAttr("grafana_dashboard_permission_item.on_team", "permission", "View"),
+					resource.TestMatchResourceAttr("grafana_dashboard_permission_item.on_user", "id", regexp.MustCompile(`^1:[a-zA-Z0-9-_]+:user:\d+$`)),
+					resource.TestCheckResourceAttrPair("grafana_dashboard_permission_item.on_user", "user_id", "grafana_user.user", "id"),
+					resource.TestCheckResourceAttr("grafana_dashboard_permission_item.on_user", "permission", "Admin"),
+				),
+			},
+			{
+				ResourceName:      "grafana_dashboard_permission_item.on_role",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "grafana_dashboard_permission_item.on_user",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "grafana_dashboard_permission_item.on_team",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,315 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
+	"github.com/grafana/grafana-openapi-client-go/client/snapshots"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceDashboardSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a snapshot of a dashboard: a frozen copy of its panels and of their data, over a given time range.
+Snapshots can't be modified, so changing any attribute replaces the snapshot.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/share-dashboards-panels/#publish-a-snapshot)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/snapshot/)
+`,
+
+		CreateContext: CreateDashboardSnapshot,
+		ReadContext:   ReadDashboardSnapshot,
+		DeleteContext: DeleteDashboardSnapshot,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"dashboard_uid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"dashboard_uid", "config_json"},
+				Description:  "The UID of the dashboard to snapshot.",
+			},
+			"config_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				StateFunc:    NormalizeDashboardConfigJSON,
+				ValidateFunc: validateDashboardConfigJSON,
+				Description:  "The dashboard model JSON to snapshot, for dashboards that aren't saved in Grafana.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the snapshot. Defaults to the title of the dashboard.",
+			},
+			"time_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "now-6h",
+				Description: "The start of the time range of the panel data.",
+			},
+			"time_to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "now",
+				Description: "The end of the time range of the panel data.",
+			},
+			"expires_in": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The number of seconds after which the snapshot expires. `0` means that the snapshot never expires. Expired snapshots are recreated on the next apply.",
+			},
+			"render_panel_data": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+				Description: "Query the data of the panels over the time range and save it in the snapshot. " +
+					"Panels whose data source is a variable, or whose queries fail, are saved without data. " +
+					"If `false`, the snapshot only holds the panels' layout.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the snapshot, used in its URL.",
+			},
+			"delete_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The key used to delete the snapshot.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the snapshot.",
+			},
+		},
+	}
+}
+
+func CreateDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	var model map[string]interface{}
+	if uid := d.Get("dashboard_uid").(string); uid != "" {
+		resp, err := client.Dashboards.GetDashboardByUID(dashboards.NewGetDashboardByUIDParams().WithUID(uid), nil)
+		if err != nil {
+			return diag.Errorf("failed to get dashboard %s: %s", uid, err)
+		}
+		var ok bool
+		if model, ok = resp.Payload.Dashboard.(map[string]interface{}); !ok {
+			return diag.Errorf("unexpected model for dashboard %s: %v", uid, resp.Payload.Dashboard)
+		}
+	} else {
+		var err error
+		if model, err = UnmarshalDashboardConfigJSON(d.Get("config_json").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	timeFrom, timeTo := d.Get("time_from").(string), d.Get("time_to").(string)
+	model["time"] = map[string]interface{}{"from": timeFrom, "to": timeTo}
+
+	var diags diag.Diagnostics
+	if d.Get("render_panel_data").(bool) {
+		panels, _ := model["panels"].([]interface{})
+		for _, panel := range allDashboardPanels(panels) {
+			if err := renderSnapshotPanelData(client, panel, timeFrom, timeTo); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("panel %s is saved without data", describePanel(panel)),
+					Detail:   err.Error(),
+				})
+			}
+		}
+	}
+
+	name := d.Get("name").(string)
+	if name == "" {
+		name, _ = model["title"].(string)
+	}
+	body := &models.CreateDashboardSnapshotCommand{
+		Dashboard: model,
+		Name:      name,
+		Expires:   int64(d.Get("expires_in").(int)),
+	}
+	resp, err := client.Snapshots.CreateDashboardSnapshot(snapshots.NewCreateDashboardSnapshotParams().WithBody(body), nil)
+	if err != nil {
+		return append(diags, diag.Errorf("failed to create dashboard snapshot: %s", err)...)
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, resp.Payload.Key))
+	d.Set("name", name)
+	d.Set("delete_key", resp.Payload.DeleteKey)
+
+	return append(diags, ReadDashboardSnapshot(ctx, d, meta)...)
+}
+
+func ReadDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	metaClient := meta.(*common.Client)
+	client, orgID, key := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	// The OpenAPI client doesn't decode the snapshot. Expired snapshots are reported as not found.
+	err := oapiRequest(client, "GET", "/snapshots/"+key, nil, nil, nil)
+	if err, shouldReturn := common.CheckReadError("dashboard snapshot", d, err); shouldReturn {
+		return err
+	}
+
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set("key", key)
+	d.Set("url", metaClient.GrafanaSubpath("/dashboard/snapshot/"+key))
+
+	return nil
+}
+
+func DeleteDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, key := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	var err error
+	if deleteKey := d.Get("delete_key").(string); deleteKey != "" {
+		_, err = client.Snapshots.DeleteDashboardSnapshotByDeleteKey(snapshots.NewDeleteDashboardSnapshotByDeleteKeyParams().WithDeleteKey(deleteKey), nil)
+	} else {
+		_, err = client.Snapshots.DeleteDashboardSnapshot(snapshots.NewDeleteDashboardSnapshotParams().WithKey(key), nil)
+	}
+	if err != nil && !common.IsOAPINotFoundError(err) {
+		return diag.Errorf("failed to delete dashboard snapshot %s: %s", key, err)
+	}
+
+	return nil
+}
+
+// renderSnapshotPanelData queries the data of a panel's targets over the given time range, through the data source query API,
+// and saves it as the panel's `snapshotData`, in the data frame format that Grafana uses for snapshots.
+func renderSnapshotPanelData(client *goapi.GrafanaHTTPAPI, panel map[string]interface{}, timeFrom, timeTo string) error {
+	targets, _ := panel["targets"].([]interface{})
+	if len(targets) == 0 || panel["type"] == "row" {
+		return nil
+	}
+
+	var queries []interface{}
+	for _, t := range targets {
+		target, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ds := target["datasource"]
+		if ds == nil || isMixedDatasource(ds) {
+			ds = panel["datasource"]
+		}
+		uid := datasourceRefUID(ds)
+		if uid == "" {
+			return fmt.Errorf("the data source of query %v isn't set", target["refId"])
+		}
+		if strings.Contains(uid, "$") {
+			return fmt.Errorf("the data source of query %v is a variable (%s), which can't be resolved", target["refId"], uid)
+		}
+		query := map[string]interface{}{"maxDataPoints": 1000, "intervalMs": 60000}
+		for k, v := range target {
+			query[k] = v
+		}
+		query["datasource"] = map[string]interface{}{"uid": uid}
+		queries = append(queries, query)
+	}
+
+	var resp struct {
+		Results map[string]struct {
+			Error  string                   `json:"error"`
+			Frames []map[string]interface{} `json:"frames"`
+		} `json:"results"`
+	}
+	body := map[string]interface{}{"queries": queries, "from": timeFrom, "to": timeTo}
+	if err := oapiRequest(client, "POST", "/ds/query", nil, body, &resp); err != nil {
+		return err
+	}
+
+	refIDs := make([]string, 0, len(resp.Results))
+	for refID := range resp.Results {
+		refIDs = append(refIDs, refID)
+	}
+	sort.Strings(refIDs)
+
+	snapshotData := []interface{}{}
+	var errs []string
+	for _, refID := range refIDs {
+		result := resp.Results[refID]
+		if result.Error != "" {
+			errs = append(errs, fmt.Sprintf("query %s: %s", refID, result.Error))
+		}
+		for _, frame := range result.Frames {
+			snapshotData = append(snapshotData, snapshotDataFrame(refID, frame))
+		}
+	}
+	panel["snapshotData"] = snapshotData
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// snapshotDataFrame converts a data frame from the query API ({schema, data} with columnar values)
+// to the format of snapshots, where each field holds its own values.
+func snapshotDataFrame(refID string, frame map[string]interface{}) map[string]interface{} {
+	frameSchema, _ := frame["schema"].(map[string]interface{})
+	data, _ := frame["data"].(map[string]interface{})
+	values, _ := data["values"].([]interface{})
+	schemaFields, _ := frameSchema["fields"].([]interface{})
+
+	fields := make([]interface{}, len(schemaFields))
+	for i, f := range schemaFields {
+		field := map[string]interface{}{"values": []interface{}{}}
+		if schemaField, ok := f.(map[string]interface{}); ok {
+			for k, v := range schemaField {
+				field[k] = v
+			}
+		}
+		if i < len(values) && values[i] != nil {
+			field["values"] = values[i]
+		}
+		fields[i] = field
+	}
+
+	result := map[string]interface{}{"refId": refID, "fields": fields}
+	if name, ok := frameSchema["name"].(string); ok && name != "" {
+		result["name"] = name
+	}
+	if frameMeta, ok := frameSchema["meta"]; ok {
+		result["meta"] = frameMeta
+	}
+	return result
+}
+
+// datasourceRefUID returns the UID (or name, in older dashboards) of a data source reference.
+func datasourceRefUID(ref interface{}) string {
+	switch r := ref.(type) {
+	case string:
+		return r
+	case map[string]interface{}:
+		uid, _ := r["uid"].(string)
+		return uid
+	}
+	return ""
+}
+
+func isMixedDatasource(ref interface{}) bool {
+	uid := datasourceRefUID(ref)
+	return uid == "-- Mixed --" || uid == "mixed"
+}
@@ -0,0 +1,68 @@
+package grafana_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/client/snapshots"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDashboardSnapshot_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccDashboardSnapshotCheckDestroy("Incident 1234 - stakeholders"),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_dashboard_snapshot/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_dashboard_snapshot.test", "key"),
+					resource.TestCheckResourceAttrSet("grafana_dashboard_snapshot.test", "delete_key"),
+					resource.TestMatchResourceAttr("grafana_dashboard_snapshot.test", "url", regexp.MustCompile(`/dashboard/snapshot/[a-zA-Z0-9]+$`)),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "name", "Incident 1234 - stakeholders"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatasourceDashboardSnapshots_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccDashboardSnapshotCheckDestroy("Snapshots data source test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_dashboard_snapshots/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_dashboard_snapshots.test", "snapshots.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_snapshots.test", "snapshots.0.name", "Snapshots data source test"),
+					resource.TestCheckResourceAttrPair("data.grafana_dashboard_snapshots.test", "snapshots.0.key", "grafana_dashboard_snapshot.test", "key"),
+					resource.TestCheckResourceAttrPair("data.grafana_dashboard_snapshots.test", "snapshots.0.url", "grafana_dashboard_snapshot.test", "url"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_snapshots.test", "snapshots.0.external", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDashboardSnapshotCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testutils.Provider.Meta().(*common.Client).GrafanaOAPI
+		resp, err := client.Snapshots.SearchDashboardSnapshots(snapshots.NewSearchDashboardSnapshotsParams().WithQuery(&name), nil)
+		if err != nil {
+			return err
+		}
+		if len(resp.Payload) > 0 {
+			return fmt.Errorf("snapshot %s still exists", name)
+		}
+		return nil
+	}
+}
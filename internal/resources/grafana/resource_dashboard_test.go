@@ -1,8 +1,11 @@
 package grafana_test
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -282,6 +285,255 @@ func TestAccDashboard_inOrg(t *testing.T) {
 	})
 }
 
+func TestAccDashboard_storeSHA256(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	uid := acctest.RandString(10)
+	expectedConfig := fmt.Sprintf(`{"title":"%[1]s","uid":"%[1]s"}`, uid)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard, 0),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardStoreSHA256(uid, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", fmt.Sprintf("%x", sha256.Sum256([]byte(expectedConfig)))),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "1"),
+				),
+			},
+			// Switching modes only migrates the state, the dashboard isn't saved again
+			{
+				Config: testAccDashboardStoreSHA256(uid, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", expectedConfig),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "1"),
+				),
+			},
+			{
+				Config: testAccDashboardStoreSHA256(uid, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", fmt.Sprintf("%x", sha256.Sum256([]byte(expectedConfig)))),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDashboard_restoreVersion(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	uid := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard, 0),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardRestoreVersion(uid, "first", 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "1"),
+				),
+			},
+			{
+				Config: testAccDashboardRestoreVersion(uid, "second", 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "2"),
+				),
+			},
+			// The configured model is ignored while a version is restored
+			{
+				Config: testAccDashboardRestoreVersion(uid, "third", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "3"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", fmt.Sprintf(`{"title":"first","uid":"%s"}`, uid)),
+				),
+			},
+			{
+				Config: testAccDashboardRestoreVersion(uid, "fourth", 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "4"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", fmt.Sprintf(`{"title":"fourth","uid":"%s"}`, uid)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDashboard_failOnExternalChange(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	uid := acctest.RandString(10)
+	externalDir := t.TempDir()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard, 0),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardFailOnExternalChange(uid, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "1"),
+				),
+			},
+			// Updates go through when the dashboard is at the version from the state
+			{
+				Config: testAccDashboardFailOnExternalChange(uid, "second", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "2"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", fmt.Sprintf(`{"title":"second","uid":"%s"}`, uid)),
+				),
+			},
+			// Toggling the flag doesn't save the dashboard again
+			{
+				Config: testAccDashboardFailOnExternalChange(uid, "second", false),
+				Check:  resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "2"),
+			},
+			{
+				Config: testAccDashboardFailOnExternalChange(uid, "second", true),
+				Check:  resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "2"),
+			},
+			// The dashboard is saved by another resource after the plan was made: the update fails and lists the remote changes
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(filepath.Join(externalDir, "external.json"), []byte(fmt.Sprintf(`{"title": "external", "uid": %q}`, uid)), 0o600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      testAccDashboardExternalChange(uid, "third", externalDir),
+				ExpectError: regexp.MustCompile(`(?s)dashboard \S+ was changed outside of Terraform since version 2.+now at version 3.+~ title: "second" -> "external"`),
+			},
+		},
+	})
+}
+
+func TestAccDashboard_validationMode(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	uid := acctest.RandString(10)
+	invalidModel := `{
+		"title": "%[1]s",
+		"uid": "%[1]s",
+		"schemaVersion": -1,
+		"panels": [
+			{"id": 1, "type": "timeseries", "title": "A", "gridPos": {"x": 0, "y": 0, "w": 12, "h": 8}, "datasource": {"uid": "does-not-exist"}},
+			{"id": 1, "type": "not-a-panel", "title": "B", "gridPos": {"x": 6, "y": 4, "w": 12, "h": 8}}
+		]
+	}`
+	validModel := `{
+		"title": "%[1]s",
+		"uid": "%[1]s",
+		"schemaVersion": 41,
+		"panels": [
+			{"id": 1, "type": "timeseries", "title": "A", "gridPos": {"x": 0, "y": 0, "w": 12, "h": 8}},
+			{"id": 2, "type": "text", "title": "B", "gridPos": {"x": 12, "y": 0, "w": 12, "h": 8}}
+		]
+	}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard, 0),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDashboardValidationMode(fmt.Sprintf(invalidModel, uid), "error"),
+				ExpectError: regexp.MustCompile(`(?s)invalid dashboard model.+schemaVersion.+same id: 1.+overlap.+unknown type: not-a-panel.+does-not-exist, which doesn't exist`),
+			},
+			{
+				Config: testAccDashboardValidationMode(fmt.Sprintf(validModel, uid), "error"),
+				Check:  testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+			},
+			// Schema versions newer than the latest known one are only reported as warnings
+			{
+				Config: testAccDashboardValidationMode(fmt.Sprintf(strings.Replace(validModel, `"schemaVersion": 41`, `"schemaVersion": 99`, 1), uid), "error"),
+				Check:  resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "2"),
+			},
+			// Issues are only reported as warnings
+			{
+				Config: testAccDashboardValidationMode(fmt.Sprintf(invalidModel, uid), "warn"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "3"),
+				),
+			},
+			// Changing the validation mode doesn't save the dashboard again
+			{
+				Config: testAccDashboardValidationMode(fmt.Sprintf(invalidModel, uid), "off"),
+				Check:  resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "3"),
+			},
+		},
+	})
+}
+
+func TestAccDashboard_changeSummary(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	uid := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard, 0),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardChangeSummary(uid, "up", "now-6h"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "change_summary.#", "0"),
+				),
+			},
+			{
+				Config: testAccDashboardChangeSummary(uid, "rate(up[5m])", "now-1h"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "change_summary.#", "2"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "change_summary.0", `~ panel "CPU": targets (A modified)`),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "change_summary.1", `~ time: {"from":"now-6h","to":"now"} -> {"from":"now-1h","to":"now"}`),
+				),
+			},
+		},
+	})
+}
+
+// The uid generated by Grafana isn't reported as a change when only the hash of the model is stored
+func TestAccDashboard_changeSummarySHA256(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	title := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard, 0),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardChangeSummarySHA256(title, "now-6h"),
+				Check:  testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+			},
+			{
+				Config: testAccDashboardChangeSummarySHA256(title, "now-1h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "change_summary.#", "1"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "change_summary.0", `~ time: {"from":"now-6h","to":"now"} -> {"from":"now-1h","to":"now"}`),
+				),
+			},
+		},
+	})
+}
+
 func testAccDashboardCheckExists(rn string, dashboard *gapi.Dashboard) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -415,6 +667,26 @@ func Test_NormalizeDashboardConfigJSON(t *testing.T) {
 			args: args{config: givenPanels},
 			want: expectedPanels,
 		},
+		{
+			name: "Panels in collapsed rows are normalized",
+			args: args{config: `{"panels":[{"id":1,"type":"row","collapsed":true,"panels":[{"id":2,"title":"nested","libraryPanel":{"name":"test","uid":"test","version":3}}]}]}`},
+			want: `{"panels":[{"collapsed":true,"panels":[{"libraryPanel":{"name":"test","uid":"test"},"title":"nested"}],"type":"row"}]}`,
+		},
+		{
+			name: "Server-populated panel fields are removed",
+			args: args{config: `{"panels":[{"title":"a","pluginVersion":"10.1.5","fieldConfig":{"defaults":{},"overrides":[]}},{"title":"b","fieldConfig":{"defaults":{"unit":"s"},"overrides":[]}}]}`},
+			want: `{"panels":[{"title":"a"},{"fieldConfig":{"defaults":{"unit":"s"},"overrides":[]},"title":"b"}]}`,
+		},
+		{
+			name: "Panels are sorted by position",
+			args: args{config: `{"panels":[{"title":"c","gridPos":{"x":0,"y":8}},{"title":"b","gridPos":{"x":12,"y":0}},{"title":"a","gridPos":{"x":0,"y":0}}]}`},
+			want: `{"panels":[{"gridPos":{"x":0,"y":0},"title":"a"},{"gridPos":{"x":12,"y":0},"title":"b"},{"gridPos":{"x":0,"y":8},"title":"c"}]}`,
+		},
+		{
+			name: "Panels are not sorted if a position is missing",
+			args: args{config: `{"panels":[{"title":"b","gridPos":{"x":0,"y":8}},{"title":"a"}]}`},
+			want: `{"panels":[{"gridPos":{"x":0,"y":8},"title":"b"},{"title":"a"}]}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -467,3 +739,91 @@ resource "grafana_dashboard" "test" {
 	})
 }`, orgName)
 }
+
+func testAccDashboardStoreSHA256(uid string, storeSHA256 bool) string {
+	return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+	store_sha256 = %[2]t
+	config_json  = jsonencode({
+		"title" : "%[1]s",
+		"uid" : "%[1]s"
+	})
+}`, uid, storeSHA256)
+}
+
+func testAccDashboardRestoreVersion(uid, title string, restoreVersion int) string {
+	return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+	restore_version = %[3]d
+	config_json     = jsonencode({
+		"title" : "%[2]s",
+		"uid" : "%[1]s"
+	})
+}`, uid, title, restoreVersion)
+}
+
+func testAccDashboardFailOnExternalChange(uid, title string, failOnExternalChange bool) string {
+	return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+	fail_on_external_change = %[3]t
+	config_json             = jsonencode({
+		"title" : "%[2]s",
+		"uid" : "%[1]s"
+	})
+}`, uid, title, failOnExternalChange)
+}
+
+// testAccDashboardExternalChange saves the dashboard from a directory, after the plan was made and before grafana_dashboard.test is updated.
+// Unlike another grafana_dashboard, the directory resource ignores the dashboard being already deleted when it's destroyed.
+func testAccDashboardExternalChange(uid, title, externalDir string) string {
+	return fmt.Sprintf(`
+resource "grafana_dashboards_directory" "external" {
+	path = %[3]q
+}
+
+resource "grafana_dashboard" "test" {
+	depends_on              = [grafana_dashboards_directory.external]
+	fail_on_external_change = true
+	config_json             = jsonencode({
+		"title" : "%[2]s",
+		"uid" : "%[1]s"
+	})
+}`, uid, title, externalDir)
+}
+
+func testAccDashboardValidationMode(model, mode string) string {
+	return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+	validation_mode = "%[2]s"
+	config_json     = <<EOT
+%[1]s
+EOT
+}`, model, mode)
+}
+
+func testAccDashboardChangeSummary(uid, expr, from string) string {
+	return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+	config_json = jsonencode({
+		title = "%[1]s"
+		uid   = "%[1]s"
+		time  = { from = "%[3]s", to = "now" }
+		panels = [{
+			title   = "CPU"
+			type    = "timeseries"
+			targets = [{ refId = "A", expr = "%[2]s" }]
+		}]
+	})
+}`, uid, expr, from)
+}
+
+func testAccDashboardChangeSummarySHA256(title, from string) string {
+	return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+	store_sha256 = true
+	config_json  = jsonencode({
+		title = "%[1]s"
+		time  = { from = "%[2]s", to = "now" }
+	})
+}`, title, from)
+}
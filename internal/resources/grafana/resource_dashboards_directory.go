@@ -0,0 +1,513 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/folders"
+	"github.com/grafana/grafana-openapi-client-go/client/search"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceDashboardsDirectory() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages all the dashboards of a directory of JSON files, as a single resource.
+
+Each ` + "`.json`" + ` file is a dashboard model. Sub-directories are mapped to (nested) folders, which are created as needed.
+The state only holds a hash of each dashboard, so that large sets of dashboards can be managed without a huge state.
+Dashboards deleted or moved to another folder outside of Terraform are saved again on the next apply.
+Other changes made outside of Terraform are only detected (and reverted) with ` + "`detect_external_changes`" + `.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard/)
+`,
+
+		CreateContext: CreateDashboardsDirectory,
+		ReadContext:   ReadDashboardsDirectory,
+		UpdateContext: UpdateDashboardsDirectory,
+		DeleteContext: DeleteDashboardsDirectory,
+		CustomizeDiff: customizeDashboardsDirectoryDiff,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The path of the directory holding the dashboard JSON files. Files and directories starting with a dot are ignored.",
+			},
+			"folder_uid": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The UID of the folder in which to save the dashboards at the root of the directory, and under which to create the folders of sub-directories. " +
+					"If not set, the root folder is used.",
+			},
+			"prune": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Set to true to delete the dashboards whose file was removed from the directory, " +
+					"as well as the folders of removed sub-directories, if they are empty. Otherwise, these dashboards and folders are only removed from the state.",
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Set a commit message for the version history of the dashboards.",
+			},
+			"detect_external_changes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Set to true to read every dashboard when refreshing, so that the changes made to them outside of Terraform are detected and reverted. " +
+					"Otherwise, the dashboards are listed with a single search, which only detects the dashboards that were deleted or moved to another folder.",
+			},
+			"files": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of the dashboard files (relative to `path`) to the sha256sum of their normalized model JSON.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"dashboard_uids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of the dashboard files (relative to `path`) to the UID of their dashboard.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"folders": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of the sub-directories (relative to `path`) to the UID of their folder.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// dashboardFile is a dashboard JSON file of a dashboards directory.
+type dashboardFile struct {
+	// dir is the directory of the file, relative to the dashboards directory. "." for the root.
+	dir        string
+	configJSON string
+	// uid is the UID set in the dashboard model, if any.
+	uid  string
+	hash string
+}
+
+func CreateDashboardsDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, orgID := ClientFromNewOrgResource(meta, d)
+	d.SetId(MakeOrgResourceID(orgID, d.Get("path").(string)))
+	if diags := reconcileDashboardsDirectory(d, meta); diags.HasError() {
+		d.SetId("")
+		return diags
+	}
+	return ReadDashboardsDirectory(ctx, d, meta)
+}
+
+func UpdateDashboardsDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := reconcileDashboardsDirectory(d, meta); diags.HasError() {
+		return diags
+	}
+	return ReadDashboardsDirectory(ctx, d, meta)
+}
+
+func ReadDashboardsDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID, _ := ClientFromExistingOrgResource(meta, d.Id())
+	oapiClient, _, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	folderUIDs := stringMap(d.Get("folders"))
+	folderUIDs["."] = d.Get("folder_uid").(string)
+	oldHashes := stringMap(d.Get("files"))
+
+	var dashboardFolderUIDs map[string]string
+	if !d.Get("detect_external_changes").(bool) {
+		var err error
+		if dashboardFolderUIDs, err = listDashboardFolderUIDs(oapiClient); err != nil {
+			return diag.Errorf("failed to list dashboards: %s", err)
+		}
+	}
+
+	// The hash of a dashboard that was deleted, moved or changed outside of Terraform no longer matches its file
+	hashes := map[string]interface{}{}
+	dashboardUIDs := map[string]interface{}{}
+	for file, uid := range stringMap(d.Get("dashboard_uids")) {
+		if dashboardFolderUIDs != nil {
+			folderUID, ok := dashboardFolderUIDs[uid]
+			if !ok {
+				continue
+			}
+			dashboardUIDs[file] = uid
+			hashes[file] = oldHashes[file]
+			if folderUID != folderUIDs[path.Dir(file)] {
+				hashes[file] = ""
+			}
+			continue
+		}
+
+		dashboard, err := client.DashboardByUID(uid)
+		if err != nil && common.IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return diag.Errorf("failed to read dashboard %s (%s): %s", uid, file, err)
+		}
+		dashboardUIDs[file] = uid
+		if dashboard.Meta.FolderUID != folderUIDs[path.Dir(file)] {
+			hashes[file] = ""
+			continue
+		}
+		hashes[file] = dashboardModelHash(dashboard.Model)
+	}
+
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set("files", hashes)
+	d.Set("dashboard_uids", dashboardUIDs)
+
+	return nil
+}
+
+func DeleteDashboardsDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, _ := ClientFromExistingOrgResource(meta, d.Id())
+	oapiClient, _, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	for file, uid := range stringMap(d.Get("dashboard_uids")) {
+		if err := client.DeleteDashboardByUID(uid); err != nil && !common.IsNotFoundError(err) {
+			return diag.Errorf("failed to delete dashboard %s (%s): %s", uid, file, err)
+		}
+	}
+
+	return diag.FromErr(deleteEmptyDirectoryFolders(oapiClient, stringMap(d.Get("folders"))))
+}
+
+func customizeDashboardsDirectoryDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("path") {
+		for _, key := range []string{"files", "dashboard_uids", "folders"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	files, err := scanDashboardsDirectory(d.Get("path").(string))
+	if err != nil {
+		return err
+	}
+
+	oldHashes, oldUIDs, oldFolders := stringMap(d.Get("files")), stringMap(d.Get("dashboard_uids")), stringMap(d.Get("folders"))
+	hashes := map[string]string{}
+	uidsKnown := len(files) == len(oldUIDs)
+	dirs := map[string]bool{}
+	for file, f := range files {
+		hashes[file] = f.hash
+		if uid, ok := oldUIDs[file]; !ok || f.uid != "" && f.uid != uid {
+			uidsKnown = false
+		}
+		for dir := f.dir; dir != "."; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	if !reflect.DeepEqual(hashes, oldHashes) {
+		if err := d.SetNew("files", hashes); err != nil {
+			return err
+		}
+	}
+	if !uidsKnown {
+		if err := d.SetNewComputed("dashboard_uids"); err != nil {
+			return err
+		}
+	}
+	for dir := range oldFolders {
+		if !dirs[dir] {
+			return d.SetNewComputed("folders")
+		}
+	}
+	for dir := range dirs {
+		if _, ok := oldFolders[dir]; !ok {
+			return d.SetNewComputed("folders")
+		}
+	}
+	return nil
+}
+
+// reconcileDashboardsDirectory saves the dashboards whose file changed (or whose remote copy changed),
+// creating the folders of sub-directories as needed, and prunes the removed ones.
+func reconcileDashboardsDirectory(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, _ := ClientFromExistingOrgResource(meta, d.Id())
+	oapiClient, _, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	files, err := scanDashboardsDirectory(d.Get("path").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldHashesI, _ := d.GetChange("files")
+	oldUIDsI, _ := d.GetChange("dashboard_uids")
+	oldFoldersI, _ := d.GetChange("folders")
+	oldHashes, oldUIDs, oldFolders := stringMap(oldHashesI), stringMap(oldUIDsI), stringMap(oldFoldersI)
+
+	rootFolderUID := d.Get("folder_uid").(string)
+	folderUIDs, err := ensureDirectoryFolders(oapiClient, files, rootFolderUID, oldFolders)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldFolders["."], folderUIDs["."] = rootFolderUID, rootFolderUID
+
+	// Save the new and changed dashboards
+	fileNames := make([]string, 0, len(files))
+	for file := range files {
+		fileNames = append(fileNames, file)
+	}
+	sort.Strings(fileNames)
+	dashboardUIDs := map[string]interface{}{}
+	for _, file := range fileNames {
+		f := files[file]
+		uid := f.uid
+		if uid == "" {
+			uid = oldUIDs[file]
+		}
+		folderUID := folderUIDs[f.dir]
+		if uid != "" && uid == oldUIDs[file] && f.hash == oldHashes[file] && folderUID == oldFolders[f.dir] {
+			dashboardUIDs[file] = uid
+			continue
+		}
+
+		dashboard, err := makeDashboardFromJSON(f.configJSON, folderUID, true, d.Get("message").(string))
+		if err != nil {
+			return diag.Errorf("invalid dashboard %s: %s", file, err)
+		}
+		if uid != "" {
+			dashboard.Model["uid"] = uid
+		}
+		resp, err := client.NewDashboard(dashboard)
+		if err != nil {
+			return diag.Errorf("failed to save dashboard %s: %s", file, err)
+		}
+		dashboardUIDs[file] = resp.UID
+	}
+	delete(folderUIDs, ".")
+
+	// Prune (or forget) the dashboards and folders that were removed from the directory
+	if d.Get("prune").(bool) {
+		savedUIDs := map[string]bool{}
+		for _, uid := range dashboardUIDs {
+			savedUIDs[uid.(string)] = true
+		}
+		// The dashboards of files whose UID changed are pruned too
+		for file, uid := range oldUIDs {
+			if savedUIDs[uid] {
+				continue
+			}
+			if err := client.DeleteDashboardByUID(uid); err != nil && !common.IsNotFoundError(err) {
+				return diag.Errorf("failed to delete dashboard %s (%s): %s", uid, file, err)
+			}
+		}
+
+		removedFolders := map[string]string{}
+		for dir, uid := range oldFolders {
+			if _, ok := folderUIDs[dir]; !ok && dir != "." {
+				removedFolders[dir] = uid
+			}
+		}
+		if err := deleteEmptyDirectoryFolders(oapiClient, removedFolders); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	hashes := map[string]interface{}{}
+	for file, f := range files {
+		hashes[file] = f.hash
+	}
+	folderUIDsI := map[string]interface{}{}
+	for dir, uid := range folderUIDs {
+		folderUIDsI[dir] = uid
+	}
+	d.Set("files", hashes)
+	d.Set("dashboard_uids", dashboardUIDs)
+	d.Set("folders", folderUIDsI)
+
+	return nil
+}
+
+// scanDashboardsDirectory reads the dashboard JSON files of a directory, recursively.
+// Files are keyed by their slash-separated path, relative to the directory.
+func scanDashboardsDirectory(basePath string) (map[string]dashboardFile, error) {
+	files := map[string]dashboardFile{}
+	err := filepath.WalkDir(basePath, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != basePath && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || filepath.Ext(p) != ".json" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(basePath, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		model, err := UnmarshalDashboardConfigJSON(string(content))
+		if err != nil {
+			return fmt.Errorf("invalid dashboard %s: %w", rel, err)
+		}
+		uid, _ := model["uid"].(string)
+		files[rel] = dashboardFile{
+			dir:        path.Dir(rel),
+			configJSON: string(content),
+			uid:        uid,
+			hash:       dashboardModelHash(model),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dashboards directory %s: %w", basePath, err)
+	}
+	return files, nil
+}
+
+// listDashboardFolderUIDs returns the folder UID of each dashboard of the organization, keyed by dashboard UID.
+// The UID of dashboards in the root folder is empty.
+func listDashboardFolderUIDs(client *goapi.GrafanaHTTPAPI) (map[string]string, error) {
+	folderUIDs := map[string]string{}
+	searchType := "dash-db"
+	limit := int64(5000)
+	for page := int64(1); ; page++ {
+		resp, err := client.Search.Search(search.NewSearchParams().WithType(&searchType).WithLimit(&limit).WithPage(&page), nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, hit := range resp.Payload {
+			folderUIDs[hit.UID] = hit.FolderUID
+		}
+		if int64(len(resp.Payload)) < limit {
+			return folderUIDs, nil
+		}
+	}
+}
+
+// dashboardModelHash returns the hash of a normalized dashboard model, without its UID.
+// The UID is tracked separately, since Grafana generates one for models that don't have it.
+func dashboardModelHash(model map[string]interface{}) string {
+	delete(model, "uid")
+	return sha256JSON(NormalizeDashboardConfigJSON(model))
+}
+
+// ensureDirectoryFolders returns the UIDs of the folders of the sub-directories, creating them as needed.
+// The folders from the state are reused if they still exist. Otherwise, existing folders with the directory's name are adopted.
+func ensureDirectoryFolders(client *goapi.GrafanaHTTPAPI, files map[string]dashboardFile, rootFolderUID string, knownFolders map[string]string) (map[string]string, error) {
+	dirs := map[string]bool{}
+	for _, f := range files {
+		for dir := f.dir; dir != "."; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+	// Parents sort before their children
+	sortedDirs := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sortedDirs = append(sortedDirs, dir)
+	}
+	sort.Strings(sortedDirs)
+
+	folderUIDs := map[string]string{".": rootFolderUID}
+	for _, dir := range sortedDirs {
+		parentUID, title := folderUIDs[path.Dir(dir)], path.Base(dir)
+
+		if uid, ok := knownFolders[dir]; ok {
+			_, err := client.Folders.GetFolderByUID(folders.NewGetFolderByUIDParams().WithFolderUID(uid), nil)
+			if err == nil {
+				folderUIDs[dir] = uid
+				continue
+			}
+			if !common.IsOAPINotFoundError(err) {
+				return nil, fmt.Errorf("failed to get folder %s (%s): %w", uid, dir, err)
+			}
+		}
+
+		subfolders, err := listSubfolders(client, parentUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list folders (%s): %w", dir, err)
+		}
+		for _, folder := range subfolders {
+			if folder.Title == title {
+				folderUIDs[dir] = folder.UID
+				break
+			}
+		}
+		if _, ok := folderUIDs[dir]; ok {
+			continue
+		}
+
+		resp, err := client.Folders.CreateFolder(folders.NewCreateFolderParams().WithBody(&models.CreateFolderCommand{
+			Title:     title,
+			ParentUID: parentUID,
+		}), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create folder %s: %w", dir, err)
+		}
+		folderUIDs[dir] = resp.Payload.UID
+	}
+
+	return folderUIDs, nil
+}
+
+// deleteEmptyDirectoryFolders deletes the given folders of sub-directories, deepest first, if they are empty.
+func deleteEmptyDirectoryFolders(client *goapi.GrafanaHTTPAPI, folderUIDs map[string]string) error {
+	dirs := make([]string, 0, len(folderUIDs))
+	for dir := range folderUIDs {
+		dirs = append(dirs, dir)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dirs)))
+
+	for _, dir := range dirs {
+		uid := folderUIDs[dir]
+		resp, err := client.Folders.GetFolderByUID(folders.NewGetFolderByUIDParams().WithFolderUID(uid), nil)
+		if common.IsOAPINotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get folder %s (%s): %w", uid, dir, err)
+		}
+		contents, err := listFolderContents(client, resp.Payload)
+		if err != nil {
+			return err
+		}
+		if !contents.isEmpty() {
+			continue
+		}
+		if _, err := client.Folders.DeleteFolder(folders.NewDeleteFolderParams().WithFolderUID(uid), nil); err != nil {
+			return fmt.Errorf("failed to delete folder %s (%s): %w", uid, dir, err)
+		}
+	}
+	return nil
+}
+
+func stringMap(v interface{}) map[string]string {
+	result := map[string]string{}
+	for k, v := range v.(map[string]interface{}) {
+		result[k] = v.(string)
+	}
+	return result
+}
@@ -0,0 +1,194 @@
+package grafana_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDashboardsDirectory_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	name := acctest.RandString(10)
+	dir := t.TempDir()
+	writeFile := func(file, content string) {
+		t.Helper()
+		p := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config := func(detectExternalChanges bool) string {
+		return fmt.Sprintf(`
+resource "grafana_dashboards_directory" "test" {
+	path                    = %q
+	prune                   = true
+	detect_external_changes = %t
+}`, dir, detectExternalChanges)
+	}
+
+	var subfolderUID string
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+			if _, err := client.DashboardByUID(name + "-a"); err == nil {
+				return fmt.Errorf("dashboard %s-a still exists", name)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					writeFile("a.json", fmt.Sprintf(`{"uid": "%[1]s-a", "title": "%[1]s a"}`, name))
+					writeFile(name+"/b.json", fmt.Sprintf(`{"title": "%[1]s b"}`, name))
+				},
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboards_directory.test", "files.%", "2"),
+					resource.TestCheckResourceAttr("grafana_dashboards_directory.test", "dashboard_uids.a.json", name+"-a"),
+					resource.TestCheckResourceAttrSet("grafana_dashboards_directory.test", "dashboard_uids."+name+"/b.json"),
+					resource.TestCheckResourceAttrSet("grafana_dashboards_directory.test", "folders."+name),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources["grafana_dashboards_directory.test"]
+						subfolderUID = rs.Primary.Attributes["folders."+name]
+						client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+						b, err := client.DashboardByUID(rs.Primary.Attributes["dashboard_uids."+name+"/b.json"])
+						if err != nil {
+							return err
+						}
+						if b.Meta.FolderUID != subfolderUID {
+							return fmt.Errorf("expected dashboard b to be in folder %s, got %s", subfolderUID, b.Meta.FolderUID)
+						}
+						return nil
+					},
+				),
+			},
+			// Folders deleted outside of Terraform are created again, along with their dashboards
+			{
+				PreConfig: func() {
+					client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+					if err := client.DeleteFolder(subfolderUID); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboards_directory.test", "files.%", "2"),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources["grafana_dashboards_directory.test"]
+						if rs.Primary.Attributes["folders."+name] == subfolderUID {
+							return fmt.Errorf("expected folder %s to be created again", name)
+						}
+						subfolderUID = rs.Primary.Attributes["folders."+name]
+						client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+						b, err := client.DashboardByUID(rs.Primary.Attributes["dashboard_uids."+name+"/b.json"])
+						if err != nil {
+							return err
+						}
+						if b.Meta.FolderUID != subfolderUID {
+							return fmt.Errorf("expected dashboard b to be in folder %s, got %s", subfolderUID, b.Meta.FolderUID)
+						}
+						return nil
+					},
+				),
+			},
+			// Dashboards deleted outside of Terraform are saved again
+			{
+				PreConfig: func() {
+					client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+					if err := client.DeleteDashboardByUID(name + "-a"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config(true), // The refresh still lists the dashboards with a search, as in the state
+				Check: func(s *terraform.State) error {
+					client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+					_, err := client.DashboardByUID(name + "-a")
+					return err
+				},
+			},
+			// Other changes made outside of Terraform are reverted when they are detected
+			{
+				PreConfig: func() {
+					client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+					if _, err := client.NewDashboard(gapi.Dashboard{
+						Model:     map[string]interface{}{"uid": name + "-a", "title": name + " changed"},
+						Overwrite: true,
+					}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config(true),
+				Check: func(s *terraform.State) error {
+					client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+					a, err := client.DashboardByUID(name + "-a")
+					if err != nil {
+						return err
+					}
+					if a.Model["title"] != name+" a" {
+						return fmt.Errorf("dashboard a wasn't reverted: %v", a.Model["title"])
+					}
+					return nil
+				},
+			},
+			// Removed files are pruned, along with their (now empty) folder
+			{
+				PreConfig: func() {
+					writeFile("a.json", fmt.Sprintf(`{"uid": "%[1]s-a", "title": "%[1]s a, updated"}`, name))
+					if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboards_directory.test", "files.%", "1"),
+					resource.TestCheckResourceAttr("grafana_dashboards_directory.test", "folders.%", "0"),
+					func(s *terraform.State) error {
+						client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+						a, err := client.DashboardByUID(name + "-a")
+						if err != nil {
+							return err
+						}
+						if a.Model["title"] != name+" a, updated" {
+							return fmt.Errorf("dashboard a wasn't updated: %v", a.Model["title"])
+						}
+						if _, err := client.FolderByUID(subfolderUID); err == nil {
+							return fmt.Errorf("folder %s wasn't pruned", subfolderUID)
+						}
+						return nil
+					},
+				),
+			},
+			// The dashboard of a file whose UID changed is pruned
+			{
+				PreConfig: func() {
+					writeFile("a.json", fmt.Sprintf(`{"uid": "%[1]s-c", "title": "%[1]s a"}`, name))
+				},
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboards_directory.test", "dashboard_uids.a.json", name+"-c"),
+					func(s *terraform.State) error {
+						client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+						if _, err := client.DashboardByUID(name + "-a"); err == nil {
+							return fmt.Errorf("dashboard %s-a wasn't pruned", name)
+						}
+						_, err := client.DashboardByUID(name + "-c")
+						return err
+					},
+				),
+			},
+		},
+	})
+}
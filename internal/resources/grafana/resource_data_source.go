@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -16,7 +17,7 @@ import (
 )
 
 func ResourceDataSource() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 
 		Description: `
 * [Official documentation](https://grafana.com/docs/grafana/latest/datasources/)
@@ -24,12 +25,32 @@ func ResourceDataSource() *schema.Resource {
 
 The required arguments for this resource vary depending on the type of data
 source selected (via the 'type' argument).
+
+For the most common types of data sources, the configuration can be set with typed blocks
+(` + "`prometheus`, `loki`, `tempo`, `elasticsearch`, `postgres`, `cloudwatch` and `influxdb`" + `)
+rather than with ` + "`json_data_encoded` and `secure_json_data_encoded`" + `. They can be combined,
+for keys that the blocks don't support.
+Imported data sources have all their configuration in ` + "`json_data_encoded`" + `: it can be moved to a typed block after the import.
 `,
 
 		CreateContext: CreateDataSource,
 		UpdateContext: UpdateDataSource,
 		DeleteContext: DeleteDataSource,
 		ReadContext:   ReadDataSource,
+		CustomizeDiff: customdiff.All(
+			validateDatasourceConfigBlock,
+			diffSecureJSONData,
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				// The health check runs on every create or update
+				if d.Id() == "" || len(d.GetChangedKeysPrefix("")) > 0 {
+					if err := d.SetNewComputed("health_status"); err != nil {
+						return err
+					}
+					return d.SetNewComputed("health_message")
+				}
+				return nil
+			},
+		),
 		SchemaVersion: 1,
 
 		Importer: &schema.ResourceImporter{
@@ -138,8 +159,65 @@ source selected (via the 'type' argument).
 					return common.SuppressEquivalentJSONDiffs(k, oldValue, newValue, d)
 				},
 			},
+			"secure_json_data_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Description: "Change this value to send the secure JSON data to Grafana again, even if it didn't change. " +
+					"Grafana doesn't return secrets, so secrets changed outside of Terraform can't be detected. Only the removed ones are.",
+			},
+			"secure_json_data_hashes": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Hashes of the secrets sent to Grafana (the fields of `secure_json_data_encoded` and the secrets of the typed configuration block), salted with the data source UID. Used to detect changes to the secrets and secrets removed from Grafana.",
+			},
+			"health_check": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Check the health of the data source after creating or updating it, the same way as the `Save & test` button of the UI.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether to check the health of the data source.",
+						},
+						"timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      30,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The timeout of the health check, in seconds.",
+						},
+						"fail_on_error": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether to fail the apply if the data source is unhealthy. Otherwise, a warning is shown.",
+						},
+					},
+				},
+			},
+			"health_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the last health check: `OK` or `ERROR`. Empty if `health_check` isn't enabled.",
+			},
+			"health_message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The message of the last health check.",
+			},
 		},
 	}
+
+	for name, blockSchema := range datasourceConfigBlockSchemas() {
+		r.Schema[name] = blockSchema
+	}
+
+	return r
 }
 
 // CreateDataSource creates a Grafana datasource
@@ -157,7 +235,14 @@ func CreateDataSource(ctx context.Context, d *schema.ResourceData, meta interfac
 	}
 
 	d.SetId(MakeOrgResourceID(orgID, id))
-	return ReadDataSource(ctx, d, meta)
+	diags := ReadDataSource(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	if err := setSecureJSONDataHashes(d); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return append(diags, datasourceHealthCheckDiags(d, meta)...)
 }
 
 // UpdateDataSource updates a Grafana datasource
@@ -169,7 +254,14 @@ func UpdateDataSource(ctx context.Context, d *schema.ResourceData, meta interfac
 		return diag.FromErr(err)
 	}
 
-	return diag.FromErr(client.UpdateDataSource(dataSource))
+	if err := client.UpdateDataSource(dataSource); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setSecureJSONDataHashes(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return datasourceHealthCheckDiags(d, meta)
 }
 
 // ReadDataSource reads a Grafana datasource
@@ -190,7 +282,11 @@ func ReadDataSource(ctx context.Context, d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	return readDatasource(d, dataSource)
+	if diags := readDatasource(d, dataSource); diags.HasError() {
+		return diags
+	}
+	oapiClient, _, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+	return diag.FromErr(readSecureJSONDataDrift(d, oapiClient))
 }
 
 // DeleteDataSource deletes a Grafana datasource
@@ -218,6 +314,9 @@ func readDatasource(d *schema.ResourceData, dataSource *gapi.DataSource) diag.Di
 	d.Set("org_id", strconv.FormatInt(dataSource.OrgID, 10))
 
 	gottenJSONData, _, gottenHeaders := gapi.ExtractHeadersFromJSONData(dataSource.JSONData, dataSource.SecureJSONData)
+	if err := readDatasourceConfigBlock(d, gottenJSONData); err != nil {
+		return diag.FromErr(err)
+	}
 	encodedJSONData, err := json.Marshal(gottenJSONData)
 	if err != nil {
 		return diag.Errorf("Failed to marshal JSON data: %s", err)
@@ -266,6 +365,7 @@ func makeDataSource(idStr string, d *schema.ResourceData) (*gapi.DataSource, err
 		return nil, err
 	}
 
+	applyDatasourceConfigBlock(d, jd, sd)
 	jd, sd = gapi.JSONDataWithHeaders(jd, sd, httpHeaders)
 
 	return &gapi.DataSource{
@@ -285,7 +385,7 @@ func makeDataSource(idStr string, d *schema.ResourceData) (*gapi.DataSource, err
 	}, err
 }
 
-func makeJSONData(d *schema.ResourceData) (map[string]interface{}, error) {
+func makeJSONData(d interface{ Get(string) interface{} }) (map[string]interface{}, error) {
 	jd := make(map[string]interface{})
 	data := d.Get("json_data_encoded")
 	if data != "" {
@@ -296,7 +396,7 @@ func makeJSONData(d *schema.ResourceData) (map[string]interface{}, error) {
 	return jd, nil
 }
 
-func makeSecureJSONData(d *schema.ResourceData) (map[string]interface{}, error) {
+func makeSecureJSONData(d interface{ Get(string) interface{} }) (map[string]interface{}, error) {
 	sjd := make(map[string]interface{})
 	data := d.Get("secure_json_data_encoded")
 	if data != "" {
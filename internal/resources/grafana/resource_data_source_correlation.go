@@ -0,0 +1,347 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/correlations"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	correlationTypeQuery    = "query"
+	correlationTypeExternal = "external"
+)
+
+func ResourceDataSourceCorrelation() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a correlation between data sources: a link from the results of a source data source to a query on a target data source, or to an external URL.
+For example, from Loki log lines to Tempo traces.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/correlations/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/correlations/)
+`,
+
+		CreateContext: CreateDataSourceCorrelation,
+		ReadContext:   ReadDataSourceCorrelation,
+		UpdateContext: UpdateDataSourceCorrelation,
+		DeleteContext: DeleteDataSourceCorrelation,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: validateDataSourceCorrelationTarget,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UID of the correlation.",
+			},
+			"source_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The UID of the data source the correlation originates from.",
+			},
+			"target_uid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"target_uid", "target_url"},
+				Description:  "The UID of the data source the correlation points to. Required for `query` correlations.",
+			},
+			"target_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The external URL the correlation points to. Required for `external` correlations. It can contain variables, such as `${traceId}`.",
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The label of the correlation, shown on the link.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the correlation.",
+			},
+			"config": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The configuration of the correlation.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      correlationTypeQuery,
+							ValidateFunc: validation.StringInSlice([]string{correlationTypeQuery, correlationTypeExternal}, false),
+							Description:  "The type of the correlation: `query`, to run a query on `target_uid`, or `external`, to open `target_url`.",
+						},
+						"field": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The field of the source results that the link is attached to.",
+						},
+						"target_json": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+							StateFunc: func(v interface{}) string {
+								json, _ := structure.NormalizeJsonString(v)
+								return json
+							},
+							DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+								if oldValue == "{}" && newValue == "" {
+									return true
+								}
+								return common.SuppressEquivalentJSONDiffs(k, oldValue, newValue, d)
+							},
+							Description: "The query to run on the target data source, as JSON. It can contain variables, such as `${traceId}`. Only for `query` correlations.",
+						},
+						"transformation": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Transformations of the source results, which extract variables that can be used in the target.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice([]string{"regex", "logfmt"}, false),
+										Description:  "The type of the transformation: `regex` or `logfmt`.",
+									},
+									"field": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The field to transform. Defaults to the field of the correlation.",
+									},
+									"expression": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The regular expression, for `regex` transformations. Its first capture group is the value of the variable.",
+									},
+									"map_value": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The name of the variable, for `regex` transformations. Defaults to the name of the field.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func CreateDataSourceCorrelation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+	sourceUID := d.Get("source_uid").(string)
+
+	config, err := makeCorrelationConfig(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body := &models.CreateCorrelationCommand{
+		Label:       d.Get("label").(string),
+		Description: d.Get("description").(string),
+		TargetUID:   d.Get("target_uid").(string),
+		Config: &models.CorrelationConfig{
+			Type:            &config.Type,
+			Field:           &config.Field,
+			Target:          config.Target,
+			Transformations: config.Transformations,
+		},
+	}
+	resp, err := client.Correlations.CreateCorrelation(correlations.NewCreateCorrelationParams().WithSourceUID(sourceUID).WithBody(body), nil)
+	if err != nil {
+		return diag.Errorf("failed to create correlation: %s", err)
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, sourceUID+":"+resp.Payload.Result.UID))
+	return ReadDataSourceCorrelation(ctx, d, meta)
+}
+
+func ReadDataSourceCorrelation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID, sourceUID, uid, err := correlationClientFromID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The correlation is read through oapiRequest so that a deleted correlation (or source data source) is reported as not found.
+	var correlation models.Correlation
+	err = oapiRequest(client, "GET", fmt.Sprintf("/datasources/uid/%s/correlations/%s", sourceUID, uid), nil, nil, &correlation)
+	if err, shouldReturn := common.CheckReadError("correlation", d, err); shouldReturn {
+		return err
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, correlation.SourceUID+":"+correlation.UID))
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set("uid", correlation.UID)
+	d.Set("source_uid", correlation.SourceUID)
+	d.Set("label", correlation.Label)
+	d.Set("description", correlation.Description)
+
+	config := map[string]interface{}{}
+	targetUID, targetURL := correlation.TargetUID, ""
+	if c := correlation.Config; c != nil {
+		if c.Type != nil {
+			config["type"] = string(*c.Type)
+		}
+		if c.Field != nil {
+			config["field"] = *c.Field
+		}
+		if config["type"] == correlationTypeExternal {
+			// External correlations hold their URL in their target
+			if target, ok := c.Target.(map[string]interface{}); ok {
+				targetURL, _ = target["url"].(string)
+			}
+			targetUID = ""
+		} else if c.Target != nil {
+			targetJSON, err := json.Marshal(c.Target)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			config["target_json"] = string(targetJSON)
+		}
+		var transformations []interface{}
+		for _, t := range c.Transformations {
+			transformations = append(transformations, map[string]interface{}{
+				"type":       t.Type,
+				"field":      t.Field,
+				"expression": t.Expression,
+				"map_value":  t.MapValue,
+			})
+		}
+		config["transformation"] = transformations
+	}
+	d.Set("target_uid", targetUID)
+	d.Set("target_url", targetURL)
+	d.Set("config", []interface{}{config})
+
+	return nil
+}
+
+func UpdateDataSourceCorrelation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, sourceUID, uid, err := correlationClientFromID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	config, err := makeCorrelationConfig(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body := &models.UpdateCorrelationCommand{
+		Label:       d.Get("label").(string),
+		Description: d.Get("description").(string),
+		Config:      config,
+	}
+	params := correlations.NewUpdateCorrelationParams().WithSourceUID(sourceUID).WithCorrelationUID(uid).WithBody(body)
+	if _, err := client.Correlations.UpdateCorrelation(params, nil); err != nil {
+		return diag.Errorf("failed to update correlation %s: %s", uid, err)
+	}
+
+	return ReadDataSourceCorrelation(ctx, d, meta)
+}
+
+func DeleteDataSourceCorrelation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, sourceUID, uid, err := correlationClientFromID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.Correlations.DeleteCorrelation(correlations.NewDeleteCorrelationParams().WithUID(sourceUID).WithCorrelationUID(uid), nil)
+	if err != nil && !common.IsOAPINotFoundError(err) {
+		return diag.Errorf("failed to delete correlation %s: %s", uid, err)
+	}
+	return nil
+}
+
+// correlationClientFromID returns a client for the org of the correlation, and the UIDs of its source data source and of the correlation.
+// The ID is in the `<org_id>:<source_uid>:<correlation_uid>` format, or `<source_uid>:<correlation_uid>` for the default org.
+func correlationClientFromID(meta interface{}, id string) (*goapi.GrafanaHTTPAPI, int64, string, string, error) {
+	parts := strings.Split(id, ":")
+	switch len(parts) {
+	case 2:
+		client, orgID, _ := OAPIClientFromExistingOrgResource(meta, "")
+		return client, orgID, parts[0], parts[1], nil
+	case 3:
+		client, orgID, _ := OAPIClientFromExistingOrgResource(meta, id)
+		return client, orgID, parts[1], parts[2], nil
+	}
+	return nil, 0, "", "", fmt.Errorf("invalid correlation ID %q, expected <source_uid>:<correlation_uid> or <org_id>:<source_uid>:<correlation_uid>", id)
+}
+
+func makeCorrelationConfig(d *schema.ResourceData) (*models.CorrelationConfigUpdateDTO, error) {
+	config := d.Get("config").([]interface{})[0].(map[string]interface{})
+	result := &models.CorrelationConfigUpdateDTO{
+		Type:            models.CorrelationConfigType(config["type"].(string)),
+		Field:           config["field"].(string),
+		Transformations: []*models.Transformation{},
+	}
+
+	if result.Type == correlationTypeExternal {
+		result.Target = map[string]interface{}{"url": d.Get("target_url").(string)}
+	} else {
+		target := map[string]interface{}{}
+		if targetJSON := config["target_json"].(string); targetJSON != "" {
+			if err := json.Unmarshal([]byte(targetJSON), &target); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal target_json: %s", err)
+			}
+		}
+		result.Target = target
+	}
+
+	for _, t := range config["transformation"].([]interface{}) {
+		transformation := t.(map[string]interface{})
+		result.Transformations = append(result.Transformations, &models.Transformation{
+			Type:       transformation["type"].(string),
+			Field:      transformation["field"].(string),
+			Expression: transformation["expression"].(string),
+			MapValue:   transformation["map_value"].(string),
+		})
+	}
+	return result, nil
+}
+
+// validateDataSourceCorrelationTarget checks that the target of the correlation matches its type.
+func validateDataSourceCorrelationTarget(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("config") || !d.NewValueKnown("target_uid") || !d.NewValueKnown("target_url") {
+		return nil
+	}
+	configs := d.Get("config").([]interface{})
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+	config := configs[0].(map[string]interface{})
+	switch config["type"] {
+	case correlationTypeQuery:
+		if d.Get("target_uid").(string) == "" {
+			return fmt.Errorf("target_uid must be set for correlations of type %s", correlationTypeQuery)
+		}
+	case correlationTypeExternal:
+		if d.Get("target_url").(string) == "" {
+			return fmt.Errorf("target_url must be set for correlations of type %s", correlationTypeExternal)
+		}
+		if config["target_json"].(string) != "" {
+			return fmt.Errorf("target_json can't be set for correlations of type %s", correlationTypeExternal)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package grafana_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCorrelation_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=10.0.0")
+
+	var query, external models.Correlation
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			correlationCheckExists.destroyed(&query, nil),
+			correlationCheckExists.destroyed(&external, nil),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_data_source_correlation/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					correlationCheckExists.exists("grafana_data_source_correlation.logs_to_traces", &query),
+					resource.TestMatchResourceAttr("grafana_data_source_correlation.logs_to_traces", "id", regexp.MustCompile(`^1:[a-zA-Z0-9-_]+:[a-zA-Z0-9-_]+$`)),
+					resource.TestCheckResourceAttrPair("grafana_data_source_correlation.logs_to_traces", "source_uid", "grafana_data_source.loki", "uid"),
+					resource.TestCheckResourceAttrPair("grafana_data_source_correlation.logs_to_traces", "target_uid", "grafana_data_source.tempo", "uid"),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_traces", "label", "Trace"),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_traces", "description", "Open the trace of the log line"),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_traces", "config.0.type", "query"),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_traces", "config.0.field", "traceId"),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_traces", "config.0.target_json", `{"query":"${traceId}"}`),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_traces", "config.0.transformation.#", "1"),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_traces", "config.0.transformation.0.type", "regex"),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_traces", "config.0.transformation.0.map_value", "traceId"),
+
+					correlationCheckExists.exists("grafana_data_source_correlation.logs_to_ci", &external),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_ci", "target_uid", ""),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_ci", "target_url", "https://ci.example.com/builds/${build}"),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_ci", "config.0.type", "external"),
+					resource.TestCheckResourceAttr("grafana_data_source_correlation.logs_to_ci", "config.0.transformation.0.type", "logfmt"),
+				),
+			},
+			{
+				ResourceName:      "grafana_data_source_correlation.logs_to_traces",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "grafana_data_source_correlation.logs_to_ci",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDataSourceCorrelation_targetValidation(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=10.0.0")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "grafana_data_source_correlation" "test" {
+	source_uid = "source"
+	target_url = "https://example.com"
+	label      = "test"
+	config {
+		field = "test"
+	}
+}`,
+				ExpectError: regexp.MustCompile("target_uid must be set for correlations of type query"),
+			},
+		},
+	})
+}
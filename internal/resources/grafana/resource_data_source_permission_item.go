@@ -0,0 +1,23 @@
+package grafana
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceDatasourcePermissionItem() *schema.Resource {
+	return resourcePermissionItem(
+		permissionItemTarget{
+			resource:     "datasources",
+			uidAttribute: "datasource_uid",
+			description:  "The UID of the data source.",
+			permissions:  []string{"Query", "Edit", "Admin"},
+		},
+		`
+Manages a single permission item for a data source: the permission of one user, team or basic role.
+Unlike `+"`grafana_data_source_permission`"+`, it doesn't remove the other permissions of the data source, so several configurations can grant access to the same data source.
+Don't use it along with `+"`grafana_data_source_permission`"+` for the same data source.
+
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/datasource_permissions/)
+`,
+	)
+}
@@ -0,0 +1,48 @@
+package grafana_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourcePermissionItem_basic(t *testing.T) {
+	testutils.CheckEnterpriseTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_data_source_permission_item/resource.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("grafana_data_source_permission_item.on_role", "datasource_uid", "grafana_data_source.foo", "uid"),
+					resource.TestMatchResourceAttr("grafana_data_source_permission_item.on_role", "id", regexp.MustCompile(`^1:[a-zA-Z0-9-_]+:role:Viewer$`)),
+					resource.TestCheckResourceAttr("grafana_data_source_permission_item.on_role", "permission", "Query"),
+					resource.TestMatchResourceAttr("grafana_data_source_permission_item.on_team", "id", regexp.MustCompile(`^1:[a-zA-Z0-9-_]+:team:\d+$`)),
+					resource.TestCheckResourceAttrPair("grafana_data_source_permission_item.on_team", "team_id", "grafana_team.team", "id"),
+					resource.TestCheckResourceAttr("grafana_data_source_permission_item.on_team", "permission", "Edit"),
+					resource.TestMatchResourceAttr("grafana_data_source_permission_item.on_user", "id", regexp.MustCompile(`^1:[a-zA-Z0-9-_]+:user:\d+$`)),
+					resource.TestCheckResourceAttrPair("grafana_data_source_permission_item.on_user", "user_id", "grafana_user.user", "id"),
+					resource.TestCheckResourceAttr("grafana_data_source_permission_item.on_user", "permission", "Edit"),
+				),
+			},
+			{
+				ResourceName:      "grafana_data_source_permission_item.on_role",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "grafana_data_source_permission_item.on_user",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "grafana_data_source_permission_item.on_team",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -95,7 +95,7 @@ func TestAccDataSource_Loki(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				// Ignore sensitive attributes, we mostly only care about "json_data_encoded"
-				ImportStateVerifyIgnore: []string{"secure_json_data_encoded", "http_headers."},
+				ImportStateVerifyIgnore: []string{"secure_json_data_encoded", "secure_json_data_version", "secure_json_data_hashes.", "http_headers."},
 			},
 			// Test import using UID
 			{
@@ -103,7 +103,7 @@ func TestAccDataSource_Loki(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				// Ignore sensitive attributes, we mostly only care about "json_data_encoded"
-				ImportStateVerifyIgnore: []string{"secure_json_data_encoded", "http_headers."},
+				ImportStateVerifyIgnore: []string{"secure_json_data_encoded", "secure_json_data_version", "secure_json_data_hashes.", "http_headers."},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					rs, ok := s.RootModule().Resources["grafana_data_source.loki"]
 					if !ok {
@@ -233,6 +233,292 @@ func TestAccDataSource_Influx(t *testing.T) {
 	})
 }
 
+func TestAccDataSource_TypedBlocks(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+	config := func(httpMethod string) string {
+		return fmt.Sprintf(`
+	resource "grafana_data_source" "prometheus" {
+		type = "prometheus"
+		name = "%[1]s"
+		url  = "http://acc-test.invalid/"
+
+		prometheus {
+			http_method             = "%[2]s"
+			time_interval           = "30s"
+			prometheus_type         = "Mimir"
+			disable_recording_rules = true
+		}
+
+		json_data_encoded = jsonencode({
+			customKey = "value"
+		})
+	}
+
+	resource "grafana_data_source" "tempo" {
+		type = "tempo"
+		name = "%[1]s-tempo"
+		url  = "http://acc-test.invalid/"
+
+		tempo {
+			service_map_datasource_uid = grafana_data_source.prometheus.uid
+			node_graph_enabled         = true
+		}
+	}`, dsName, httpMethod)
+	}
+
+	checkJSONData := func(expected map[string]interface{}) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			if !reflect.DeepEqual(dataSource.JSONData, expected) {
+				return fmt.Errorf("bad JSON data: %#v. Expected: %#v", dataSource.JSONData, expected)
+			}
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config("POST"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.prometheus", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "prometheus.0.http_method", "POST"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "prometheus.0.time_interval", "30s"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data_encoded", `{"customKey":"value"}`),
+					checkJSONData(map[string]interface{}{
+						"httpMethod":            "POST",
+						"timeInterval":          "30s",
+						"prometheusType":        "Mimir",
+						"disableRecordingRules": true,
+						"customKey":             "value",
+					}),
+					datasourceCheckExists.exists("grafana_data_source.tempo", &dataSource),
+					resource.TestCheckResourceAttrPair("grafana_data_source.tempo", "tempo.0.service_map_datasource_uid", "grafana_data_source.prometheus", "uid"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "tempo.0.node_graph_enabled", "true"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data_encoded", "{}"),
+				),
+			},
+			{
+				Config: config("GET"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.prometheus", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "prometheus.0.http_method", "GET"),
+				),
+			},
+			// The keys of the typed block are imported in the JSON data
+			{
+				ResourceName:            "grafana_data_source.prometheus",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"prometheus", "json_data_encoded"},
+			},
+			{
+				ResourceName:            "grafana_data_source.tempo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"tempo", "json_data_encoded"},
+			},
+		},
+	})
+}
+
+func TestAccDataSource_TypedBlocksValidation(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+	resource "grafana_data_source" "test" {
+		type = "loki"
+		name = "typed-blocks-validation"
+		prometheus {
+			http_method = "POST"
+		}
+	}`,
+				ExpectError: regexp.MustCompile("the prometheus block can't be used with data sources of type loki"),
+			},
+			{
+				Config: `
+	resource "grafana_data_source" "test" {
+		type = "prometheus"
+		name = "typed-blocks-validation"
+		prometheus {
+			http_method = "POST"
+		}
+		json_data_encoded = jsonencode({
+			httpMethod = "GET"
+		})
+	}`,
+				ExpectError: regexp.MustCompile("json_data_encoded sets keys managed by the prometheus block: httpMethod"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_HealthCheck(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+	resource "grafana_data_source" "test" {
+		type = "grafana-testdata-datasource"
+		name = "%s"
+		health_check {}
+	}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.test", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.test", "health_check.0.enabled", "true"),
+					resource.TestCheckResourceAttr("grafana_data_source.test", "health_check.0.timeout", "30"),
+					resource.TestCheckResourceAttr("grafana_data_source.test", "health_status", "OK"),
+					resource.TestCheckResourceAttrSet("grafana_data_source.test", "health_message"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+	resource "grafana_data_source" "test" {
+		type = "grafana-testdata-datasource"
+		name = "%s"
+		health_check {
+			enabled = false
+		}
+	}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.test", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.test", "health_status", ""),
+					resource.TestCheckResourceAttr("grafana_data_source.test", "health_message", ""),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+	resource "grafana_data_source" "test" {
+		type = "prometheus"
+		name = "%s"
+		url  = "http://acc-test.invalid/"
+		health_check {
+			timeout       = 10
+			fail_on_error = true
+		}
+	}`, dsName),
+				ExpectError: regexp.MustCompile("failed its health check"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_SecureJSONDataDrift(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+	var postgresPasswordHash string
+	config := func(version int, postgresPassword string) string {
+		return fmt.Sprintf(`
+	resource "grafana_data_source" "test" {
+		type = "prometheus"
+		name = "%[1]s"
+		url  = "http://acc-test.invalid/"
+		basic_auth_enabled  = true
+		basic_auth_username = "user"
+		secure_json_data_encoded = jsonencode({
+			basicAuthPassword = "password"
+		})
+		secure_json_data_version = %[2]d
+	}
+
+	resource "grafana_data_source" "postgres" {
+		type          = "grafana-postgresql-datasource"
+		name          = "%[1]s-postgres"
+		url           = "postgres.invalid:5432"
+		username      = "user"
+		database_name = "db"
+
+		postgres {
+			password = "%[3]s"
+		}
+	}`, dsName, version, postgresPassword)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config(1, "password"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.test", &dataSource),
+					resource.TestCheckResourceAttrSet("grafana_data_source.test", "secure_json_data_hashes.basicAuthPassword"),
+					resource.TestCheckResourceAttrWith("grafana_data_source.postgres", "secure_json_data_hashes.password", func(hash string) error {
+						postgresPasswordHash = hash
+						return nil
+					}),
+				),
+			},
+			// Changing a secret of the typed block changes its hash
+			{
+				Config: config(1, "new-password"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("grafana_data_source.postgres", "secure_json_data_hashes.password", func(hash string) error {
+						if hash == postgresPasswordHash {
+							return fmt.Errorf("expected the hash of the password to change")
+						}
+						return nil
+					}),
+				),
+			},
+			// Clear the secret outside of Terraform. The drift is detected.
+			{
+				PreConfig: func() {
+					client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+					ds, err := client.DataSourceByUID(dataSource.UID)
+					if err != nil {
+						t.Fatal(err)
+					}
+					ds.SecureJSONData = map[string]interface{}{"basicAuthPassword": ""}
+					if err := client.UpdateDataSource(ds); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config(1, "new-password"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(1, "new-password"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.test", &dataSource),
+					func(s *terraform.State) error {
+						if !dataSource.SecureJSONFields["basicAuthPassword"] {
+							return fmt.Errorf("expected the secret to be set again")
+						}
+						return nil
+					},
+				),
+			},
+			// Bumping the version sends the secrets again
+			{
+				Config: config(2, "new-password"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_data_source.test", "secure_json_data_version", "2"),
+					resource.TestCheckResourceAttrSet("grafana_data_source.test", "secure_json_data_hashes.basicAuthPassword"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccDataSource_changeUID(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
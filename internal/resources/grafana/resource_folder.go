@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client/folders"
-	"github.com/grafana/grafana-openapi-client-go/client/search"
 	"github.com/grafana/grafana-openapi-client-go/models"
 
 	"github.com/grafana/terraform-provider-grafana/internal/common"
@@ -53,13 +52,21 @@ func ResourceFolder() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "Prevent deletion of the folder if it is not empty (contains dashboards or alert rules).",
+				Description: "Prevent deletion of the folder if it is not empty (contains dashboards, alert rules, library panels or subfolders, checked recursively).",
+			},
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Delete the contents of the folder (alert rules, dashboards, library panels and subfolders, recursively) before deleting it. " +
+					"Without it, deleting the folder fails if Grafana can't delete some of its contents along with it, such as library panels used by dashboards. " +
+					"Has no effect if `prevent_destroy_if_not_empty` is set.",
 			},
 			"parent_folder_uid": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "The uid of the parent folder. If set, the folder will be nested. If not set, the folder will be created in the root folder.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The uid of the parent folder. If set, the folder will be nested. If not set, the folder will be created in the root folder. " +
+					"Changing it moves the folder (along with its contents) without recreating it.",
 			},
 		},
 	}
@@ -101,15 +108,30 @@ func UpdateFolder(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag.Errorf("failed to get folder %s: %s", idStr, err)
 	}
 
-	params := goapi.NewUpdateFolderParams().
-		WithBody(&models.UpdateFolderCommand{
-			Overwrite: true,
-			Title:     d.Get("title").(string),
-		}).
-		WithFolderUID(folder.UID)
+	if d.HasChange("title") {
+		params := goapi.NewUpdateFolderParams().
+			WithBody(&models.UpdateFolderCommand{
+				Overwrite: true,
+				Title:     d.Get("title").(string),
+			}).
+			WithFolderUID(folder.UID)
 
-	if _, err := client.Folders.UpdateFolder(params, nil); err != nil {
-		return diag.FromErr(err)
+		if _, err := client.Folders.UpdateFolder(params, nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("parent_folder_uid") {
+		// An empty parent UID moves the folder to the root folder
+		params := goapi.NewMoveFolderParams().
+			WithBody(&models.MoveFolderCommand{
+				ParentUID: d.Get("parent_folder_uid").(string),
+			}).
+			WithFolderUID(folder.UID)
+
+		if _, err := client.Folders.MoveFolder(params, nil); err != nil {
+			return diag.Errorf("failed to move folder %s: %s", folder.UID, err)
+		}
 	}
 
 	return ReadFolder(ctx, d, meta)
@@ -137,26 +159,28 @@ func ReadFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 func DeleteFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 	deleteParams := goapi.NewDeleteFolderParams().WithFolderUID(d.Get("uid").(string))
-	if d.Get("prevent_destroy_if_not_empty").(bool) {
-		// Search for dashboards and fail if any are found
-		folderID, err := strconv.ParseInt(idStr, 10, 64)
+
+	preventDestroy, forceDestroy := d.Get("prevent_destroy_if_not_empty").(bool), d.Get("force_destroy").(bool)
+	if preventDestroy || forceDestroy {
+		folder, err := GetFolderByIDorUID(client.Folders, idStr)
 		if err != nil {
-			return diag.Errorf("failed to parse folder ID: %s", err)
+			return diag.Errorf("failed to get folder %s: %s", idStr, err)
 		}
-		searchType := "dash-db"
-		searchParams := search.NewSearchParams().WithFolderIds([]int64{folderID}).WithType(&searchType)
-		searchResp, err := client.Search.Search(searchParams, nil)
+		contents, err := listFolderContents(client, folder)
 		if err != nil {
-			return diag.Errorf("failed to search for dashboards in folder: %s", err)
+			return diag.FromErr(err)
 		}
-		if len(searchResp.GetPayload()) > 0 {
-			var dashboardNames []string
-			for _, dashboard := range searchResp.GetPayload() {
-				dashboardNames = append(dashboardNames, dashboard.Title)
+		if preventDestroy && !contents.isEmpty() {
+			return notEmptyFolderDiags(contents)
+		}
+		if forceDestroy {
+			if err := deleteFolderContents(client, contents); err != nil {
+				return diag.Errorf("failed to delete the contents of folder %s: %s", folder.UID, err)
 			}
-			return diag.Errorf("folder %s is not empty and prevent_destroy_if_not_empty is set. It contains the following dashboards: %v", d.Get("uid").(string), dashboardNames)
 		}
-	} else {
+	}
+
+	if !preventDestroy {
 		// If we're not preventing destroys, then we can force delete folders that have alert rules
 		force := true
 		deleteParams.WithForceDeleteRules(&force)
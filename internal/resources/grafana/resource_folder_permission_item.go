@@ -0,0 +1,24 @@
+package grafana
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceFolderPermissionItem() *schema.Resource {
+	return resourcePermissionItem(
+		permissionItemTarget{
+			resource:     "folders",
+			uidAttribute: "folder_uid",
+			description:  "The UID of the folder.",
+			permissions:  []string{"View", "Edit", "Admin"},
+		},
+		`
+Manages a single permission item for a folder: the permission of one user, team or basic role.
+Unlike `+"`grafana_folder_permission`"+`, it doesn't remove the other permissions of the folder, so several configurations can grant access to the same folder.
+Don't use it along with `+"`grafana_folder_permission`"+` for the same folder.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/roles-and-permissions/access-control/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/folder_permissions/)
+`,
+	)
+}
@@ -53,13 +53,13 @@ func TestAccFolder_basic(t *testing.T) {
 				ResourceName:            "grafana_folder.test_folder",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
+				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty", "force_destroy"},
 			},
 			{
 				ResourceName:            "grafana_folder.test_folder_with_uid",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
+				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty", "force_destroy"},
 			},
 			// Change the title of a folder. This shouldn't change the ID (the folder doesn't have to be recreated)
 			{
@@ -104,6 +104,7 @@ func TestAccFolder_nested(t *testing.T) {
 	var parentFolder goapi.Folder
 	var childFolder1 goapi.Folder
 	var childFolder2 goapi.Folder
+	var movedFolder goapi.Folder
 	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -152,24 +153,70 @@ resource grafana_folder child2 {
 				ResourceName:            "grafana_folder.parent",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
+				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty", "force_destroy"},
 			},
 			{
 				ResourceName:            "grafana_folder.child1",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
+				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty", "force_destroy"},
 			},
 			{
 				ResourceName:            "grafana_folder.child2",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
+				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty", "force_destroy"},
+			},
+			// Move child2 directly under the parent, then to the root. The folder isn't recreated.
+			{
+				Config: testAccFolderNestedMove(name, "grafana_folder.parent.uid"),
+				Check: resource.ComposeTestCheckFunc(
+					folderCheckExists.exists("grafana_folder.child2", &movedFolder),
+					func(s *terraform.State) error {
+						if movedFolder.ID != childFolder2.ID {
+							return fmt.Errorf("expected folder %d to be moved, got new folder %d", childFolder2.ID, movedFolder.ID)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttrPtr("grafana_folder.child2", "parent_folder_uid", &parentFolder.UID),
+				),
+			},
+			{
+				Config: testAccFolderNestedMove(name, `""`),
+				Check: resource.ComposeTestCheckFunc(
+					folderCheckExists.exists("grafana_folder.child2", &movedFolder),
+					func(s *terraform.State) error {
+						if movedFolder.ID != childFolder2.ID {
+							return fmt.Errorf("expected folder %d to be moved, got new folder %d", childFolder2.ID, movedFolder.ID)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr("grafana_folder.child2", "parent_folder_uid", ""),
+				),
 			},
 		},
 	})
 }
 
+func testAccFolderNestedMove(name, child2Parent string) string {
+	return fmt.Sprintf(`
+resource grafana_folder parent {
+	title = "Nested Test: Parent %[1]s"
+}
+
+resource grafana_folder child1 {
+	title = "Nested Test: Child 1 %[1]s"
+	uid = "%[1]s-child1"
+	parent_folder_uid = grafana_folder.parent.uid
+}
+
+resource grafana_folder child2 {
+	title = "Nested Test: Child 2 %[1]s"
+	parent_folder_uid = %[2]s
+}
+`, name, child2Parent)
+}
+
 func TestAccFolder_PreventDeletion(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
@@ -208,7 +255,7 @@ func TestAccFolder_PreventDeletion(t *testing.T) {
 				Config:  testAccFolderExample_PreventDeletion(name, true),
 				Destroy: true, // Try to delete the protected folder
 				ExpectError: regexp.MustCompile(
-					fmt.Sprintf(`.+folder %s is not empty and prevent_destroy_if_not_empty is set.+`, name),
+					fmt.Sprintf(`(?s)folder %[1]s is not empty and prevent_destroy_if_not_empty is set.+Dashboards \(1\):.+"%[1]s-dashboard"`, name),
 				), // Fail because it's protected
 			},
 			{
@@ -222,6 +269,70 @@ func TestAccFolder_PreventDeletion(t *testing.T) {
 	})
 }
 
+func TestAccFolder_ForceDestroy(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	var folder goapi.Folder
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			folderCheckExists.destroyed(&folder, nil),
+			func(s *terraform.State) error {
+				client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+				if _, err := client.DashboardByUID(name + "-dashboard"); err == nil {
+					return fmt.Errorf("dashboard %s-dashboard still exists", name)
+				}
+				if _, err := client.LibraryPanelByUID(name + "-panel"); err == nil {
+					return fmt.Errorf("library panel %s-panel still exists", name)
+				}
+				return nil
+			},
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "grafana_folder" "test_folder" {
+	uid           = "%[1]s"
+	title         = "%[1]s"
+	force_destroy = true
+}`, name),
+				Check: resource.ComposeTestCheckFunc(
+					folderCheckExists.exists("grafana_folder.test_folder", &folder),
+					// Create a library panel, used by a dashboard, in the folder
+					func(s *terraform.State) error {
+						client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+						if _, err := client.NewLibraryPanel(gapi.LibraryPanel{
+							Folder: folder.ID,
+							UID:    name + "-panel",
+							Name:   name + "-panel",
+							Model:  map[string]interface{}{"title": name + "-panel", "type": "text"},
+						}); err != nil {
+							return err
+						}
+						_, err := client.NewDashboard(gapi.Dashboard{
+							FolderUID: folder.UID,
+							FolderID:  folder.ID,
+							Model: map[string]interface{}{
+								"uid":   name + "-dashboard",
+								"title": name + "-dashboard",
+								"panels": []interface{}{
+									map[string]interface{}{
+										"id":           1,
+										"gridPos":      map[string]interface{}{"h": 8, "w": 12, "x": 0, "y": 0},
+										"libraryPanel": map[string]interface{}{"uid": name + "-panel", "name": name + "-panel"},
+									},
+								},
+							}})
+						return err
+					},
+				),
+			},
+		},
+	})
+}
+
 // This is a bug in Grafana, not the provider. It was fixed in 9.2.7+ and 9.3.0+, this test will check for regressions
 func TestAccFolder_createFromDifferentRoles(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">=9.2.7")
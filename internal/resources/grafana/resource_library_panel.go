@@ -3,12 +3,17 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
 	"github.com/grafana/grafana-openapi-client-go/client/library_elements"
+	"github.com/grafana/grafana-openapi-client-go/client/search"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/internal/common"
 )
@@ -27,6 +32,13 @@ Manages Grafana library panels.
 		ReadContext:   readLibraryPanel,
 		UpdateContext: updateLibraryPanel,
 		DeleteContext: deleteLibraryPanel,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// Moving the library panel with `folder_id` changes its folder UID
+			if d.Id() != "" && d.HasChange("folder_id") && !d.HasChange("folder_uid") {
+				return d.SetNewComputed("folder_uid")
+			}
+			return nil
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -47,15 +59,27 @@ Manages Grafana library panels.
 				Description: "The numeric ID of the library panel computed by Grafana.",
 			},
 			"folder_id": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "ID of the folder where the library panel is stored.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"folder_uid"},
+				Description:   "ID of the folder where the library panel is stored. Prefer `folder_uid`, which also works with nested folders.",
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// The folder is managed through `folder_uid`
+					if libraryPanelFolderUIDConfigured(d) {
+						return true
+					}
 					_, old = SplitOrgResourceID(old)
 					_, new = SplitOrgResourceID(new)
 					return old == "0" && new == "" || old == "" && new == "0" || old == new
 				},
 			},
+			"folder_uid": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"folder_id"},
+				Description:   "Unique ID (UID) of the folder where the library panel is stored. Leave empty for the General folder.",
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -72,11 +96,18 @@ Manages Grafana library panels.
 				Description: "Type of the library panel (eg. text).",
 			},
 			"model_json": {
-				Type:         schema.TypeString,
-				Required:     true,
-				StateFunc:    normalizeLibraryPanelModelJSON,
-				ValidateFunc: validateLibraryPanelModelJSON,
-				Description:  "The JSON model for the library panel.",
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        normalizeLibraryPanelModelJSON,
+				ValidateFunc:     validateLibraryPanelModelJSON,
+				DiffSuppressFunc: diffSuppressSHA256JSON,
+				Description:      "The JSON model for the library panel.",
+			},
+			"store_sha256": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Set to true to store only the sha256sum of `model_json` in the state, instead of the complete library panel model JSON. " +
+					"The provider's `store_dashboard_sha256` setting doesn't apply to library panels.",
 			},
 			"version": {
 				Type:        schema.TypeInt,
@@ -88,11 +119,6 @@ Manages Grafana library panels.
 				Computed:    true,
 				Description: "Name of the folder containing the library panel.",
 			},
-			"folder_uid": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Unique ID (UID) of the folder containing the library panel.",
-			},
 			"created": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -109,6 +135,19 @@ Manages Grafana library panels.
 				Description: "Numerical IDs of Grafana dashboards containing the library panel.",
 				Elem:        &schema.Schema{Type: schema.TypeInt},
 			},
+			"dashboard_uids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "UIDs of Grafana dashboards containing the library panel.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"force_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Unlink the library panel from the dashboards containing it before deleting it: their panels are replaced with a copy of the library panel's model. " +
+					"Without it, deleting a library panel used by dashboards fails.",
+			},
 		},
 	}
 }
@@ -146,6 +185,10 @@ func readLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interfac
 		return diag.FromErr(err)
 	}
 	modelJSON := normalizeLibraryPanelModelJSON(remotePanelJSON)
+	// The provider's setting only applies to dashboards, so that the state of existing library panels doesn't change
+	if storeSHA256(d, false) {
+		modelJSON = sha256JSON(modelJSON)
+	}
 
 	d.SetId(MakeOrgResourceID(orgID, uid))
 	d.Set("uid", panel.UID)
@@ -175,23 +218,34 @@ func readLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interfac
 	}
 	d.Set("dashboard_ids", dashboardIds)
 
+	dashboardUIDs, err := libraryPanelDashboardUIDs(client, connections)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("dashboard_uids", dashboardUIDs)
+
 	return nil
 }
 
 func updateLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
 
-	modelJSON := d.Get("model_json").(string)
+	// Switching between storing the model JSON and its hash only changes the state, and force_delete is only used on deletion
+	if !d.HasChangesExcept("store_sha256", "force_delete") {
+		return readLibraryPanel(ctx, d, meta)
+	}
+
+	modelJSON := configuredModelJSON(d, "model_json")
 	panelJSON, _ := unmarshalLibraryPanelModelJSON(modelJSON)
 
-	_, folderIDStr := SplitOrgResourceID(d.Get("folder_id").(string))
-	folderID, _ := strconv.ParseInt(folderIDStr, 10, 64)
+	folderID, folderUID := libraryPanelFolder(d)
 	params := library_elements.NewUpdateLibraryElementParams().WithLibraryElementUID(uid).WithBody(&models.PatchLibraryElementCommand{
-		Name:     d.Get("name").(string),
-		FolderID: folderID,
-		Model:    panelJSON,
-		Kind:     1,
-		Version:  int64(d.Get("version").(int)),
+		Name:      d.Get("name").(string),
+		FolderID:  folderID,
+		FolderUID: folderUID,
+		Model:     panelJSON,
+		Kind:      1,
+		Version:   int64(d.Get("version").(int)),
 	})
 	resp, err := client.LibraryElements.UpdateLibraryElement(params, nil)
 	if err != nil {
@@ -204,28 +258,144 @@ func updateLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interf
 
 func deleteLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	connResp, err := client.LibraryElements.GetLibraryElementConnections(library_elements.NewGetLibraryElementConnectionsParams().WithLibraryElementUID(uid), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if connections := connResp.Payload.Result; len(connections) > 0 {
+		dashboardUIDs, err := libraryPanelDashboardUIDs(client, connections)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !d.Get("force_delete").(bool) {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("library panel %s is used by %d dashboard(s)", uid, len(connections)),
+				Detail: fmt.Sprintf("Dashboard UIDs: %s\n\nRemove the library panel from these dashboards, or set `force_delete` to unlink it from them before deleting it.",
+					strings.Join(dashboardUIDs, ", ")),
+			}}
+		}
+
+		panelResp, err := client.LibraryElements.GetLibraryElementByUID(library_elements.NewGetLibraryElementByUIDParams().WithLibraryElementUID(uid), nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		panelModel, _ := panelResp.Payload.Result.Model.(map[string]interface{})
+		for _, dashboardUID := range dashboardUIDs {
+			if err := unlinkLibraryPanel(client, dashboardUID, uid, panelModel); err != nil {
+				return diag.Errorf("failed to unlink library panel %s from dashboard %s: %s", uid, dashboardUID, err)
+			}
+		}
+	}
+
 	params := library_elements.NewDeleteLibraryElementByUIDParams().WithLibraryElementUID(uid)
-	_, err := client.LibraryElements.DeleteLibraryElementByUID(params, nil)
+	_, err = client.LibraryElements.DeleteLibraryElementByUID(params, nil)
 	return diag.FromErr(err)
 }
 
 func makeLibraryPanel(d *schema.ResourceData) models.CreateLibraryElementCommand {
-	modelJSON := d.Get("model_json").(string)
+	modelJSON := configuredModelJSON(d, "model_json")
 	panelJSON, _ := unmarshalLibraryPanelModelJSON(modelJSON)
 
-	_, folderIDStr := SplitOrgResourceID(d.Get("folder_id").(string))
-	folderID, _ := strconv.ParseInt(folderIDStr, 10, 64)
+	folderID, folderUID := libraryPanelFolder(d)
 	panel := models.CreateLibraryElementCommand{
-		UID:      d.Get("uid").(string),
-		Name:     d.Get("name").(string),
-		FolderID: folderID,
-		Model:    panelJSON,
-		Kind:     1,
+		UID:       d.Get("uid").(string),
+		Name:      d.Get("name").(string),
+		FolderID:  folderID,
+		FolderUID: folderUID,
+		Model:     panelJSON,
+		Kind:      1,
 	}
 
 	return panel
 }
 
+// libraryPanelFolder returns the folder of the library panel: either its UID if `folder_uid` is configured, or its ID.
+func libraryPanelFolder(d *schema.ResourceData) (int64, string) {
+	if libraryPanelFolderUIDConfigured(d) {
+		return 0, d.Get("folder_uid").(string)
+	}
+	_, folderIDStr := SplitOrgResourceID(d.Get("folder_id").(string))
+	folderID, _ := strconv.ParseInt(folderIDStr, 10, 64)
+	return folderID, ""
+}
+
+func libraryPanelFolderUIDConfigured(d *schema.ResourceData) bool {
+	raw := d.GetRawConfig()
+	if !raw.IsKnown() || raw.IsNull() {
+		return false
+	}
+	folderUID := raw.GetAttr("folder_uid")
+	// An unknown value will be set once known
+	return !folderUID.IsKnown() || !folderUID.IsNull()
+}
+
+// libraryPanelDashboardUIDs returns the UIDs of the dashboards connected to a library panel.
+// Older Grafana versions only return the ID of the dashboards, so they are looked up.
+func libraryPanelDashboardUIDs(client *goapi.GrafanaHTTPAPI, connections []*models.LibraryElementConnectionDTO) ([]string, error) {
+	uids := make([]string, 0, len(connections))
+	var missingIDs []int64
+	for _, connection := range connections {
+		if connection.ConnectionUID != "" {
+			uids = append(uids, connection.ConnectionUID)
+		} else {
+			missingIDs = append(missingIDs, connection.ConnectionID)
+		}
+	}
+	if len(missingIDs) == 0 {
+		return uids, nil
+	}
+
+	resp, err := client.Search.Search(search.NewSearchParams().WithDashboardIds(missingIDs), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the dashboards containing the library panel: %w", err)
+	}
+	for _, hit := range resp.Payload {
+		uids = append(uids, hit.UID)
+	}
+	return uids, nil
+}
+
+// unlinkLibraryPanel replaces the instances of a library panel in a dashboard with a copy of its model, then saves the dashboard.
+func unlinkLibraryPanel(client *goapi.GrafanaHTTPAPI, dashboardUID, panelUID string, panelModel map[string]interface{}) error {
+	resp, err := client.Dashboards.GetDashboardByUID(dashboards.NewGetDashboardByUIDParams().WithUID(dashboardUID), nil)
+	if err != nil {
+		return err
+	}
+	model, ok := resp.Payload.Dashboard.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected model: %v", resp.Payload.Dashboard)
+	}
+
+	panels, _ := model["panels"].([]interface{})
+	for _, panel := range allDashboardPanels(panels) {
+		libraryPanel, ok := panel["libraryPanel"].(map[string]interface{})
+		if !ok || libraryPanel["uid"] != panelUID {
+			continue
+		}
+		delete(panel, "libraryPanel")
+		for k, v := range panelModel {
+			// The position and id belong to the dashboard
+			if k == "gridPos" || k == "id" {
+				continue
+			}
+			if _, ok := panel[k]; !ok {
+				panel[k] = v
+			}
+		}
+	}
+
+	body := &models.SaveDashboardCommand{
+		Dashboard: model,
+		FolderUID: resp.Payload.Meta.FolderUID,
+		Overwrite: true,
+		Message:   fmt.Sprintf("Unlinked library panel %s", panelUID),
+	}
+	_, err = client.Dashboards.PostDashboard(dashboards.NewPostDashboardParams().WithBody(body), nil)
+	return err
+}
+
 // unmarshalLibraryPanelModelJSON is a convenience func for unmarshalling
 // `model_json` field.
 func unmarshalLibraryPanelModelJSON(modelJSON string) (map[string]interface{}, error) {
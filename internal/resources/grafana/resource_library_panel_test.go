@@ -1,7 +1,9 @@
 package grafana_test
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"regexp"
 	"testing"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
@@ -50,9 +52,10 @@ func TestAccLibraryPanel_basic(t *testing.T) {
 			},
 			{
 				// Importing matches the state of the previous step.
-				ResourceName:      "grafana_library_panel.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "grafana_library_panel.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_delete"},
 			},
 		},
 	})
@@ -109,6 +112,31 @@ func TestAccLibraryPanel_folder(t *testing.T) {
 	})
 }
 
+func TestAccLibraryPanel_folderUID(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=8.0.0")
+
+	var panel models.LibraryElementResponse
+	var folder models.Folder
+
+	// TODO: Make parallelizable
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      testAccLibraryPanelFolderCheckDestroy(&panel, &folder),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_library_panel/_acc_folder_uid.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					libraryPanelCheckExists.exists("grafana_library_panel.test_folder", &panel),
+					folderCheckExists.exists("grafana_folder.test_folder", &folder),
+					testAccLibraryPanelCheckExistsInFolder(&panel, &folder),
+					resource.TestCheckResourceAttrPair("grafana_library_panel.test_folder", "folder_uid", "grafana_folder.test_folder", "uid"),
+					resource.TestCheckResourceAttrPair("grafana_library_panel.test_folder", "folder_id", "grafana_folder.test_folder", "id"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccLibraryPanel_dashboard(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">=8.0.0")
 
@@ -134,6 +162,83 @@ func TestAccLibraryPanel_dashboard(t *testing.T) {
 	})
 }
 
+func TestAccLibraryPanel_forceDelete(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=8.0.0")
+
+	var panel models.LibraryElementResponse
+	name := acctest.RandString(10)
+	dashboardUID := name + "-dashboard"
+
+	// TODO: Make parallelizable
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+			return client.DeleteDashboardByUID(dashboardUID)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLibraryPanelForceDelete(name, 1, false),
+				Check:  libraryPanelCheckExists.exists("grafana_library_panel.test.0", &panel),
+			},
+			// The dashboard is created outside of Terraform
+			{
+				PreConfig: func() {
+					client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+					_, err := client.NewDashboard(gapi.Dashboard{
+						Model: map[string]interface{}{
+							"uid":   dashboardUID,
+							"title": name,
+							"panels": []interface{}{map[string]interface{}{
+								"id":           1,
+								"gridPos":      map[string]interface{}{"x": 0, "y": 0, "w": 12, "h": 8},
+								"libraryPanel": map[string]interface{}{"uid": name, "name": name},
+							}},
+						},
+						Overwrite: true,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccLibraryPanelForceDelete(name, 1, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_library_panel.test.0", "dashboard_uids.#", "1"),
+					resource.TestCheckResourceAttr("grafana_library_panel.test.0", "dashboard_uids.0", dashboardUID),
+				),
+			},
+			{
+				Config:      testAccLibraryPanelForceDelete(name, 0, false),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`library panel %s is used by 1 dashboard`, name)),
+			},
+			{
+				Config: testAccLibraryPanelForceDelete(name, 1, true),
+			},
+			{
+				Config: testAccLibraryPanelForceDelete(name, 0, true),
+				Check: resource.ComposeTestCheckFunc(
+					libraryPanelCheckExists.destroyed(&panel, nil),
+					func(s *terraform.State) error {
+						client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+						dashboard, err := client.DashboardByUID(dashboardUID)
+						if err != nil {
+							return err
+						}
+						dashboardPanel := dashboard.Model["panels"].([]interface{})[0].(map[string]interface{})
+						if _, ok := dashboardPanel["libraryPanel"]; ok {
+							return fmt.Errorf("expected the library panel to be unlinked from the dashboard")
+						}
+						if dashboardPanel["title"] != name {
+							return fmt.Errorf("expected the dashboard panel to hold a copy of the library panel, got %v", dashboardPanel)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccLibraryPanel_inOrg(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">=8.0.0")
 
@@ -156,6 +261,38 @@ func TestAccLibraryPanel_inOrg(t *testing.T) {
 	})
 }
 
+func TestAccLibraryPanel_storeSHA256(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=8.0.0")
+
+	var panel models.LibraryElementResponse
+	name := acctest.RandString(10)
+	expectedModel := fmt.Sprintf(`{"description":"","title":"%s","type":""}`, name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      libraryPanelCheckExists.destroyed(&panel, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLibraryPanelStoreSHA256(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					libraryPanelCheckExists.exists("grafana_library_panel.test", &panel),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "model_json", fmt.Sprintf("%x", sha256.Sum256([]byte(expectedModel)))),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "version", "1"),
+				),
+			},
+			// Switching modes only migrates the state, the panel isn't saved again
+			{
+				Config: testAccLibraryPanelStoreSHA256(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					libraryPanelCheckExists.exists("grafana_library_panel.test", &panel),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "model_json", expectedModel),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "version", "1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccLibraryPanelCheckExistsInFolder(panel *models.LibraryElementResponse, folder *models.Folder) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if panel.Result.FolderID != folder.ID && folder.ID != 0 {
@@ -196,3 +333,28 @@ resource "grafana_library_panel" "test" {
 	})
   }`, orgName)
 }
+
+func testAccLibraryPanelStoreSHA256(name string, storeSHA256 bool) string {
+	return fmt.Sprintf(`
+resource "grafana_library_panel" "test" {
+	name         = "%[1]s"
+	store_sha256 = %[2]t
+	model_json   = jsonencode({
+		title = "%[1]s"
+	})
+}`, name, storeSHA256)
+}
+
+func testAccLibraryPanelForceDelete(name string, count int, forceDelete bool) string {
+	return fmt.Sprintf(`
+resource "grafana_library_panel" "test" {
+	count        = %[2]d
+	uid          = "%[1]s"
+	name         = "%[1]s"
+	force_delete = %[3]t
+	model_json   = jsonencode({
+		title = "%[1]s"
+		type  = "text"
+	})
+}`, name, count, forceDelete)
+}
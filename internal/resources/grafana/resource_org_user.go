@@ -0,0 +1,174 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceOrgUser() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages the membership of a single user in an organization, and their role in it.
+Unlike the ` + "`admins`, `editors`, `viewers` and `users_without_access`" + ` attributes of ` + "`grafana_organization`" + `, it doesn't remove the other users of the organization.
+To use both, set ` + "`ignore_externally_managed_users`" + ` on the organization.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/organization-management/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/org/#add-user-in-organization)
+
+This resource uses Grafana's admin APIs.
+It does not work with API tokens or service accounts which are org-scoped.
+You must use basic auth.
+`,
+
+		CreateContext: CreateOrgUser,
+		ReadContext:   ReadOrgUser,
+		UpdateContext: UpdateOrgUser,
+		DeleteContext: DeleteOrgUser,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"email", "login"},
+				Description:  "The email of the user. The user must already exist in Grafana.",
+			},
+			"login": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"email", "login"},
+				Description:  "The login of the user. The user must already exist in Grafana.",
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Admin", "Editor", "Viewer", "None"}, false),
+				Description:  "The role of the user in the organization. Options: `Admin`, `Editor`, `Viewer` or `None` (`None` is only available in Grafana 10.2+).",
+			},
+			"user_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the user.",
+			},
+		},
+	}
+}
+
+func CreateOrgUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaAPI
+	_, orgID := OAPIClientFromNewOrgResource(meta, d)
+	email, login, role := d.Get("email").(string), d.Get("login").(string), d.Get("role").(string)
+
+	loginOrEmail := email
+	if loginOrEmail == "" {
+		loginOrEmail = login
+	}
+	// Users that are already in the organization (e.g. the admin user, or users added to their default organization) are adopted.
+	alreadyMember := false
+	if err := client.AddOrgUser(orgID, loginOrEmail, role); err != nil {
+		if !strings.HasPrefix(err.Error(), "status: 409") {
+			return diag.Errorf("failed to add user %s to organization %d: %s", loginOrEmail, orgID, err)
+		}
+		alreadyMember = true
+	}
+
+	user, err := findOrgUser(client, orgID, func(u gapi.OrgUser) bool {
+		return (email != "" && u.Email == email) || (login != "" && u.Login == login)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if user == nil {
+		return diag.Errorf("user %s was added to organization %d, but can't be found in it", loginOrEmail, orgID)
+	}
+	d.SetId(MakeOrgResourceID(orgID, user.UserID))
+
+	if alreadyMember && user.Role != role {
+		if err := client.UpdateOrgUser(orgID, user.UserID, role); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadOrgUser(ctx, d, meta)
+}
+
+func ReadOrgUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaAPI
+	_, orgID, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
+	userID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return diag.Errorf("invalid user ID %q: %s", idStr, err)
+	}
+
+	user, err := findOrgUser(client, orgID, func(u gapi.OrgUser) bool { return u.UserID == userID })
+	if err, shouldReturn := common.CheckReadError("organization user", d, err); shouldReturn {
+		return err
+	}
+	if user == nil {
+		log.Printf("[WARN] removing organization user %s from state because it no longer exists in grafana", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, userID))
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set("user_id", userID)
+	d.Set("email", user.Email)
+	d.Set("login", user.Login)
+	d.Set("role", user.Role)
+
+	return nil
+}
+
+func UpdateOrgUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaAPI
+	_, orgID, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
+	userID, _ := strconv.ParseInt(idStr, 10, 64)
+
+	if err := client.UpdateOrgUser(orgID, userID, d.Get("role").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ReadOrgUser(ctx, d, meta)
+}
+
+func DeleteOrgUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaAPI
+	_, orgID, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
+	userID, _ := strconv.ParseInt(idStr, 10, 64)
+
+	err := client.RemoveOrgUser(orgID, userID)
+	diags, _ := common.CheckReadError("organization user", d, err)
+	return diags
+}
+
+// findOrgUser returns the first user of the organization that matches, or nil if there's none.
+func findOrgUser(client *gapi.Client, orgID int64, match func(gapi.OrgUser) bool) (*gapi.OrgUser, error) {
+	users, err := client.OrgUsers(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the users of organization %d: %w", orgID, err)
+	}
+	for i := range users {
+		if match(users[i]) {
+			return &users[i], nil
+		}
+	}
+	return nil, nil
+}
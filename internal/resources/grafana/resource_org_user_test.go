@@ -0,0 +1,45 @@
+package grafana_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOrgUser_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	config := testutils.TestAccExample(t, "resources/grafana_org_user/resource.tf")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					// The user managed by grafana_org_user is ignored by the organization
+					resource.TestCheckResourceAttr("grafana_organization.org", "editors.#", "0"),
+					resource.TestCheckResourceAttrPair("grafana_org_user.user", "org_id", "grafana_organization.org", "org_id"),
+					resource.TestCheckResourceAttrPair("grafana_org_user.user", "user_id", "grafana_user.user", "user_id"),
+					resource.TestCheckResourceAttr("grafana_org_user.user", "email", "user@example.com"),
+					resource.TestCheckResourceAttr("grafana_org_user.user", "login", "user"),
+					resource.TestCheckResourceAttr("grafana_org_user.user", "role", "Editor"),
+				),
+			},
+			{
+				Config: strings.Replace(config, `role   = "Editor"`, `role   = "Viewer"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_org_user.user", "role", "Viewer"),
+					resource.TestCheckResourceAttr("grafana_organization.org", "viewers.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "grafana_org_user.user",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -84,6 +84,16 @@ parameter defaults to true, creating placeholder users with the name, login,
 and email set to the email of the user, and a random password. Setting this
 option to false will cause an error to be thrown for any users that do not
 already exist in Grafana.
+`,
+			},
+			"ignore_externally_managed_users": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: `
+Only manage the users listed in 'admins', 'editors', 'viewers' and 'users_without_access'.
+Other users of the organization, for example the ones managed by grafana_org_user resources,
+are left untouched.
 `,
 			},
 			"org_id": {
@@ -212,7 +222,18 @@ func ReadUsers(d *schema.ResourceData, meta interface{}) error {
 	}
 	roleMap := map[string][]string{"Admin": nil, "Editor": nil, "Viewer": nil, "None": nil}
 	grafAdmin := d.Get("admin_user")
+	// Users that aren't listed in any role are managed elsewhere
+	managedUsers := map[string]bool{}
+	for _, role := range []string{"admins", "editors", "viewers", "users_without_access"} {
+		for _, email := range d.Get(role).(*schema.Set).List() {
+			managedUsers[email.(string)] = true
+		}
+	}
+	ignoreExternallyManaged := d.Get("ignore_externally_managed_users").(bool)
 	for _, orgUser := range orgUsers {
+		if ignoreExternallyManaged && !managedUsers[orgUser.Email] {
+			continue
+		}
 		if orgUser.Login != grafAdmin {
 			roleMap[orgUser.Role] = append(roleMap[orgUser.Role], orgUser.Email)
 		}
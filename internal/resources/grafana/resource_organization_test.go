@@ -50,7 +50,7 @@ func TestAccOrganization_basic(t *testing.T) {
 				ResourceName:            "grafana_organization.test",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"admins", "admin_user", "create_users"}, // Users are imported explicitly (with create_users == false)
+				ImportStateVerifyIgnore: []string{"admins", "admin_user", "create_users", "ignore_externally_managed_users"}, // Users are imported explicitly (with create_users == false)
 				ImportStateCheck: func(s []*terraform.InstanceState) error {
 					if len(s) != 1 {
 						return fmt.Errorf("expected 1 state: %#v", s)
@@ -284,7 +284,7 @@ func TestAccOrganization_defaultAdmin(t *testing.T) {
 				ResourceName:            "grafana_organization.test",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"admin_user", "create_user"}, // These are provider-side attributes and aren't returned by the API
+				ImportStateVerifyIgnore: []string{"admin_user", "create_user", "ignore_externally_managed_users"}, // These are provider-side attributes and aren't returned by the API
 			},
 		},
 	})
@@ -0,0 +1,226 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	permissionItemUser = "user"
+	permissionItemTeam = "team"
+	permissionItemRole = "role"
+)
+
+// permissionItemTarget describes a kind of resource whose permissions can be granted one at a time,
+// through the `/api/access-control/<resource>/<uid>/<users|teams|builtInRoles>/<id>` endpoints.
+type permissionItemTarget struct {
+	// resource is the name of the resource in the access control API: folders, dashboards or datasources.
+	resource string
+	// uidAttribute is the attribute that holds the UID of the resource.
+	uidAttribute string
+	description  string
+	permissions  []string
+}
+
+// resourcePermission is an entry of the permissions of a resource, as returned by the access control API.
+type resourcePermission struct {
+	UserID      int64  `json:"userId"`
+	TeamID      int64  `json:"teamId"`
+	BuiltInRole string `json:"builtInRole"`
+	Permission  string `json:"permission"`
+	IsManaged   bool   `json:"isManaged"`
+	IsInherited bool   `json:"isInherited"`
+}
+
+func resourcePermissionItem(target permissionItemTarget, description string) *schema.Resource {
+	assignments := []string{"user_id", "team_id", "role"}
+	return &schema.Resource{
+		Description: description,
+
+		CreateContext: target.create,
+		ReadContext:   target.read,
+		UpdateContext: target.update,
+		DeleteContext: target.delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			target.uidAttribute: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: target.description,
+			},
+			"user_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: assignments,
+				Description:  "ID of the user or service account to grant the permission to.",
+			},
+			"team_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: assignments,
+				Description:  "ID of the team to grant the permission to.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					_, old = SplitOrgResourceID(old)
+					_, new = SplitOrgResourceID(new)
+					return old == new
+				},
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: assignments,
+				ValidateFunc: validation.StringInSlice([]string{"Viewer", "Editor", "Admin"}, false),
+				Description:  "Name of the basic role to grant the permission to. Options: `Viewer`, `Editor` or `Admin`.",
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(target.permissions, false),
+				Description:  fmt.Sprintf("The permission to grant. Options: `%s`.", strings.Join(target.permissions, "`, `")),
+			},
+		},
+	}
+}
+
+func (t permissionItemTarget) create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	var kind, assignee string
+	if v := d.Get("user_id").(string); v != "" {
+		_, assignee = SplitOrgResourceID(v)
+		kind = permissionItemUser
+	} else if v := d.Get("team_id").(string); v != "" {
+		_, assignee = SplitOrgResourceID(v)
+		kind = permissionItemTeam
+	} else {
+		assignee = d.Get("role").(string)
+		kind = permissionItemRole
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, strings.Join([]string{d.Get(t.uidAttribute).(string), kind, assignee}, ":")))
+	if err := t.set(meta, d.Id(), d.Get("permission").(string)); err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+
+	return t.read(ctx, d, meta)
+}
+
+func (t permissionItemTarget) read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID, uid, kind, assignee, err := t.splitID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var permissions []resourcePermission
+	err = oapiRequest(client, "GET", fmt.Sprintf("/access-control/%s/%s", t.resource, uid), nil, nil, &permissions)
+	if err, shouldReturn := common.CheckReadError(t.resource+" permission item", d, err); shouldReturn {
+		return err
+	}
+
+	// Only the permission that is directly granted to the assignee is managed, not the ones it inherits from parent folders.
+	var found *resourcePermission
+	for i, p := range permissions {
+		if !p.IsManaged || p.IsInherited {
+			continue
+		}
+		if (kind == permissionItemUser && strconv.FormatInt(p.UserID, 10) == assignee) ||
+			(kind == permissionItemTeam && strconv.FormatInt(p.TeamID, 10) == assignee) ||
+			(kind == permissionItemRole && p.BuiltInRole == assignee) {
+			found = &permissions[i]
+			break
+		}
+	}
+	if found == nil {
+		log.Printf("[WARN] removing %s permission item %s from state because it no longer exists in grafana", t.resource, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, strings.Join([]string{uid, kind, assignee}, ":")))
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set(t.uidAttribute, uid)
+	// Keep the org ID prefix of the user or team ID, if it was set that way.
+	// Team IDs are otherwise set with the org ID prefix, like the ID of grafana_team. The prefix is ignored when diffing.
+	switch kind {
+	case permissionItemUser:
+		if _, current := SplitOrgResourceID(d.Get("user_id").(string)); current != assignee {
+			d.Set("user_id", assignee)
+		}
+	case permissionItemTeam:
+		if _, current := SplitOrgResourceID(d.Get("team_id").(string)); current != assignee {
+			d.Set("team_id", MakeOrgResourceID(orgID, assignee))
+		}
+	case permissionItemRole:
+		d.Set("role", assignee)
+	}
+	d.Set("permission", found.Permission)
+
+	return nil
+}
+
+func (t permissionItemTarget) update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := t.set(meta, d.Id(), d.Get("permission").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	return t.read(ctx, d, meta)
+}
+
+func (t permissionItemTarget) delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// An empty permission removes the grant
+	err := t.set(meta, d.Id(), "")
+	diags, _ := common.CheckReadError(t.resource+" permission item", d, err)
+	return diags
+}
+
+// set grants a permission to the assignee of the item. Other grants on the resource are left untouched.
+func (t permissionItemTarget) set(meta interface{}, id, permission string) error {
+	client, _, uid, kind, assignee, err := t.splitID(meta, id)
+	if err != nil {
+		return err
+	}
+	assignmentKind := map[string]string{
+		permissionItemUser: "users",
+		permissionItemTeam: "teams",
+		permissionItemRole: "builtInRoles",
+	}[kind]
+	path := fmt.Sprintf("/access-control/%s/%s/%s/%s", t.resource, uid, assignmentKind, assignee)
+	return oapiRequest(client, "POST", path, nil, map[string]string{"permission": permission}, nil)
+}
+
+// splitID splits the ID of a permission item: `<org_id>:<uid>:<user|team|role>:<user ID, team ID or role name>`.
+// The org ID can be omitted when importing, to use the default org.
+func (t permissionItemTarget) splitID(meta interface{}, id string) (client *goapi.GrafanaHTTPAPI, orgID int64, uid, kind, assignee string, err error) {
+	parts := strings.Split(id, ":")
+	switch len(parts) {
+	case 3:
+		client, orgID, _ = OAPIClientFromExistingOrgResource(meta, "")
+		uid, kind, assignee = parts[0], parts[1], parts[2]
+	case 4:
+		client, orgID, _ = OAPIClientFromExistingOrgResource(meta, id)
+		uid, kind, assignee = parts[1], parts[2], parts[3]
+	default:
+		return nil, 0, "", "", "", fmt.Errorf("invalid ID %q, expected [<org_id>:]<%s>:<user|team|role>:<assignee>", id, t.uidAttribute)
+	}
+	if kind != permissionItemUser && kind != permissionItemTeam && kind != permissionItemRole {
+		return nil, 0, "", "", "", fmt.Errorf("invalid ID %q: %q isn't one of user, team or role", id, kind)
+	}
+	return client, orgID, uid, kind, assignee, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"strconv"
+	"time"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/grafana/terraform-provider-grafana/internal/common"
@@ -11,17 +12,32 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// serviceAccountTokenExpirationLayout is the layout of the `expiration` attribute.
+const serviceAccountTokenExpirationLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
 func ResourceServiceAccountToken() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Description: `
 **Note:** This resource is available only with Grafana 9.1+.
 
+Tokens with an expiration can be rotated automatically with ` + "`rotate_before`" + `.
+` + "`create_before_destroy`" + ` must then be set, so that the new key is available before the old token is deleted.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/administration/service-accounts/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/serviceaccount/#service-account-api)`,
 
 		CreateContext: serviceAccountTokenCreate,
 		ReadContext:   serviceAccountTokenRead,
+		UpdateContext: serviceAccountTokenUpdate,
 		DeleteContext: serviceAccountTokenDelete,
+		CustomizeDiff: common.TokenRotationCustomizeDiff("key", []string{"seconds_to_live"}, func(d *schema.ResourceDiff) (time.Time, bool, error) {
+			expiration := d.Get("expiration").(string)
+			if expiration == "" {
+				return time.Time{}, false, nil
+			}
+			t, err := time.Parse(serviceAccountTokenExpirationLayout, expiration)
+			return t, err == nil, err
+		}),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -54,6 +70,10 @@ func ResourceServiceAccountToken() *schema.Resource {
 			},
 		},
 	}
+	for k, v := range common.TokenRotationSchema("seconds_to_live") {
+		r.Schema[k] = v
+	}
+	return r
 }
 
 func serviceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -64,7 +84,7 @@ func serviceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
-	name := d.Get("name").(string)
+	name := common.RotatedTokenName(d)
 	ttl := d.Get("seconds_to_live").(int)
 
 	request := gapi.CreateServiceAccountTokenRequest{
@@ -107,12 +127,12 @@ func serviceAccountTokenRead(ctx context.Context, d *schema.ResourceData, m inte
 	for _, key := range response {
 		if id == key.ID {
 			d.SetId(strconv.FormatInt(key.ID, 10))
-			err = d.Set("name", key.Name)
+			err = d.Set("name", common.TokenNameFromAPI(d, key.Name))
 			if err != nil {
 				return diag.FromErr(err)
 			}
 			if key.Expiration != nil && !key.Expiration.IsZero() {
-				err = d.Set("expiration", key.Expiration.String())
+				err = d.Set("expiration", key.Expiration.Format(serviceAccountTokenExpirationLayout))
 				if err != nil {
 					return diag.FromErr(err)
 				}
@@ -129,6 +149,11 @@ func serviceAccountTokenRead(ctx context.Context, d *schema.ResourceData, m inte
 	return nil
 }
 
+// serviceAccountTokenUpdate only updates the rotation settings, which are only used when planning.
+func serviceAccountTokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return serviceAccountTokenRead(ctx, d, m)
+}
+
 func serviceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	orgID, serviceAccountIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
 	c := m.(*common.Client).GrafanaAPI.WithOrgID(orgID)
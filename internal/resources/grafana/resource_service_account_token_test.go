@@ -2,7 +2,9 @@ package grafana_test
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
@@ -99,6 +101,74 @@ func TestAccServiceAccountToken_inOrg(t *testing.T) {
 	})
 }
 
+func TestAccServiceAccountToken_rotation(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
+
+	name := acctest.RandString(10)
+	var sa models.ServiceAccountDTO
+	var firstID string
+
+	// The token expires in less than `rotate_before`, so it's replaced on every plan
+	config := fmt.Sprintf(`
+resource "grafana_service_account" "test" {
+	name = "%[1]s"
+	role = "Viewer"
+}
+
+resource "grafana_service_account_token" "test" {
+	name               = "%[1]s"
+	service_account_id = grafana_service_account.test.id
+	seconds_to_live    = 300
+	rotate_before      = "10m"
+
+	lifecycle {
+		create_before_destroy = true
+	}
+}
+`, name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			serviceAccountCheckExists.destroyed(&sa, nil),
+			testAccServiceAccountTokenCheckDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					serviceAccountCheckExists.exists("grafana_service_account.test", &sa),
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "name", name),
+					resource.TestCheckResourceAttrSet("grafana_service_account_token.test", "expiration"),
+					resource.TestCheckResourceAttrWith("grafana_service_account_token.test", "id", func(value string) error {
+						firstID = value
+						return nil
+					}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "name", name),
+					resource.TestCheckResourceAttrWith("grafana_service_account_token.test", "id", func(value string) error {
+						if value == firstID {
+							return fmt.Errorf("expected the token to be rotated")
+						}
+						return nil
+					}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      strings.Replace(config, "seconds_to_live    = 300", "", 1),
+				ExpectError: regexp.MustCompile("rotate_before requires seconds_to_live to be set"),
+				PlanOnly:    true,
+			},
+		},
+	})
+}
+
 func testAccServiceAccountTokenCheckDestroy(s *terraform.State) error {
 	c := testutils.Provider.Meta().(*common.Client).GrafanaAPI
 
@@ -0,0 +1,595 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	ssoOAuth2SettingsBlock = "oauth2_settings"
+	ssoSAMLSettingsBlock   = "saml_settings"
+	ssoLDAPSettingsBlock   = "ldap_settings"
+)
+
+// ssoSettingsBlocks maps each SSO provider to the settings block that configures it.
+var ssoSettingsBlocks = map[string]string{
+	"github":        ssoOAuth2SettingsBlock,
+	"gitlab":        ssoOAuth2SettingsBlock,
+	"google":        ssoOAuth2SettingsBlock,
+	"azuread":       ssoOAuth2SettingsBlock,
+	"okta":          ssoOAuth2SettingsBlock,
+	"generic_oauth": ssoOAuth2SettingsBlock,
+	"saml":          ssoSAMLSettingsBlock,
+	"ldap":          ssoLDAPSettingsBlock,
+}
+
+// ssoCustomURLProviders are the OAuth2 providers that have no default endpoints, so they must be configured.
+var ssoCustomURLProviders = []string{"azuread", "okta", "generic_oauth"}
+
+// ssoSettingsField maps an attribute of a settings block to a key of the SSO settings API.
+type ssoSettingsField struct {
+	attr        string
+	key         string
+	valueType   schema.ValueType
+	description string
+	required    bool
+	validate    schema.SchemaValidateFunc
+	// secret fields are redacted by Grafana, so their values are never read back.
+	secret bool
+}
+
+var ssoOAuth2SettingsFields = []ssoSettingsField{
+	{attr: "client_id", key: "clientId", valueType: schema.TypeString, description: "The client ID of the OAuth2 application.", required: true},
+	{attr: "client_secret", key: "clientSecret", valueType: schema.TypeString, description: "The client secret of the OAuth2 application.", secret: true},
+	{attr: "name", key: "name", valueType: schema.TypeString, description: "The name of the provider, shown on the login page."},
+	{attr: "enabled", key: "enabled", valueType: schema.TypeBool, description: "Whether the provider is enabled."},
+	{attr: "auth_url", key: "authUrl", valueType: schema.TypeString, description: "The authorization endpoint of the provider. Required for `azuread`, `okta` and `generic_oauth`.", validate: validation.IsURLWithHTTPorHTTPS},
+	{attr: "token_url", key: "tokenUrl", valueType: schema.TypeString, description: "The token endpoint of the provider. Required for `azuread`, `okta` and `generic_oauth`.", validate: validation.IsURLWithHTTPorHTTPS},
+	{attr: "api_url", key: "apiUrl", valueType: schema.TypeString, description: "The user information endpoint of the provider.", validate: validation.IsURLWithHTTPorHTTPS},
+	{attr: "scopes", key: "scopes", valueType: schema.TypeString, description: "Comma- or space-separated OAuth2 scopes."},
+	{attr: "empty_scopes", key: "emptyScopes", valueType: schema.TypeBool, description: "Whether to request no scopes."},
+	{attr: "auth_style", key: "authStyle", valueType: schema.TypeString, description: "How the client credentials are sent to the token endpoint: `AutoDetect`, `InParams` or `InHeader`.", validate: validation.StringInSlice([]string{"AutoDetect", "InParams", "InHeader"}, false)},
+	{attr: "allow_sign_up", key: "allowSignUp", valueType: schema.TypeBool, description: "Whether to create Grafana users for new users of the provider."},
+	{attr: "auto_login", key: "autoLogin", valueType: schema.TypeBool, description: "Whether to log in with the provider without showing the login page."},
+	{attr: "signout_redirect_url", key: "signoutRedirectUrl", valueType: schema.TypeString, description: "The URL users are redirected to after signing out.", validate: validation.IsURLWithHTTPorHTTPS},
+	{attr: "allowed_domains", key: "allowedDomains", valueType: schema.TypeString, description: "Comma- or space-separated email domains that are allowed to log in."},
+	{attr: "allowed_organizations", key: "allowedOrganizations", valueType: schema.TypeString, description: "Comma- or space-separated organizations of the provider whose members are allowed to log in."},
+	{attr: "allowed_groups", key: "allowedGroups", valueType: schema.TypeString, description: "Comma- or space-separated groups whose members are allowed to log in."},
+	{attr: "team_ids", key: "teamIds", valueType: schema.TypeString, description: "Comma- or space-separated team IDs of the provider whose members are allowed to log in."},
+	{attr: "teams_url", key: "teamsUrl", valueType: schema.TypeString, description: "The endpoint listing the teams of the user, for `generic_oauth`.", validate: validation.IsURLWithHTTPorHTTPS},
+	{attr: "team_ids_attribute_path", key: "teamIdsAttributePath", valueType: schema.TypeString, description: "The JMESPath expression extracting the team IDs of the user, for `generic_oauth`."},
+	{attr: "role_attribute_path", key: "roleAttributePath", valueType: schema.TypeString, description: "The JMESPath expression extracting the Grafana role of the user."},
+	{attr: "role_attribute_strict", key: "roleAttributeStrict", valueType: schema.TypeBool, description: "Whether to deny the login of users whose role can't be extracted."},
+	{attr: "org_attribute_path", key: "orgAttributePath", valueType: schema.TypeString, description: "The JMESPath expression extracting the organizations of the user."},
+	{attr: "org_mapping", key: "orgMapping", valueType: schema.TypeString, description: "Comma- or space-separated mappings of the provider's organizations to Grafana organizations and roles. For example, `engineering:2:Editor`."},
+	{attr: "allow_assign_grafana_admin", key: "allowAssignGrafanaAdmin", valueType: schema.TypeBool, description: "Whether the role attribute path can make users Grafana server admins."},
+	{attr: "skip_org_role_sync", key: "skipOrgRoleSync", valueType: schema.TypeBool, description: "Whether to stop synchronizing the organization roles of users from the provider."},
+	{attr: "email_attribute_name", key: "emailAttributeName", valueType: schema.TypeString, description: "The name of the attribute holding the email of the user, for `generic_oauth`."},
+	{attr: "email_attribute_path", key: "emailAttributePath", valueType: schema.TypeString, description: "The JMESPath expression extracting the email of the user, for `generic_oauth`."},
+	{attr: "login_attribute_path", key: "loginAttributePath", valueType: schema.TypeString, description: "The JMESPath expression extracting the login of the user, for `generic_oauth`."},
+	{attr: "name_attribute_path", key: "nameAttributePath", valueType: schema.TypeString, description: "The JMESPath expression extracting the name of the user, for `generic_oauth`."},
+	{attr: "groups_attribute_path", key: "groupsAttributePath", valueType: schema.TypeString, description: "The JMESPath expression extracting the groups of the user."},
+	{attr: "id_token_attribute_name", key: "idTokenAttributeName", valueType: schema.TypeString, description: "The name of the attribute of the token response holding the ID token, for `generic_oauth`."},
+	{attr: "use_pkce", key: "usePkce", valueType: schema.TypeBool, description: "Whether to use Proof Key for Code Exchange (PKCE)."},
+	{attr: "use_refresh_token", key: "useRefreshToken", valueType: schema.TypeBool, description: "Whether to refresh the access tokens of users with refresh tokens."},
+	{attr: "tls_skip_verify_insecure", key: "tlsSkipVerifyInsecure", valueType: schema.TypeBool, description: "Whether to skip the verification of the provider's TLS certificate."},
+	{attr: "tls_client_cert", key: "tlsClientCert", valueType: schema.TypeString, description: "The path of the TLS client certificate."},
+	{attr: "tls_client_key", key: "tlsClientKey", valueType: schema.TypeString, description: "The path of the TLS client key."},
+	{attr: "tls_client_ca", key: "tlsClientCa", valueType: schema.TypeString, description: "The path of the TLS CA certificate."},
+}
+
+var ssoSAMLSettingsFields = []ssoSettingsField{
+	{attr: "name", key: "name", valueType: schema.TypeString, description: "The name of the provider, shown on the login page."},
+	{attr: "enabled", key: "enabled", valueType: schema.TypeBool, description: "Whether the provider is enabled."},
+	{attr: "entity_id", key: "entityId", valueType: schema.TypeString, description: "The entity ID of the service provider, which is Grafana."},
+	{attr: "certificate", key: "certificate", valueType: schema.TypeString, description: "The base64-encoded certificate of the service provider."},
+	{attr: "certificate_path", key: "certificatePath", valueType: schema.TypeString, description: "The path of the certificate of the service provider."},
+	{attr: "private_key", key: "privateKey", valueType: schema.TypeString, description: "The base64-encoded private key of the service provider.", secret: true},
+	{attr: "private_key_path", key: "privateKeyPath", valueType: schema.TypeString, description: "The path of the private key of the service provider."},
+	{attr: "signature_algorithm", key: "signatureAlgorithm", valueType: schema.TypeString, description: "The algorithm signing the requests: `rsa-sha1`, `rsa-sha256` or `rsa-sha512`.", validate: validation.StringInSlice([]string{"rsa-sha1", "rsa-sha256", "rsa-sha512"}, false)},
+	{attr: "idp_metadata", key: "idpMetadata", valueType: schema.TypeString, description: "The base64-encoded metadata of the identity provider."},
+	{attr: "idp_metadata_path", key: "idpMetadataPath", valueType: schema.TypeString, description: "The path of the metadata of the identity provider."},
+	{attr: "idp_metadata_url", key: "idpMetadataUrl", valueType: schema.TypeString, description: "The URL of the metadata of the identity provider.", validate: validation.IsURLWithHTTPorHTTPS},
+	{attr: "max_issue_delay", key: "maxIssueDelay", valueType: schema.TypeString, description: "The maximum time between the issue of a request and the reception of the response. For example, `90s`."},
+	{attr: "metadata_valid_duration", key: "metadataValidDuration", valueType: schema.TypeString, description: "How long the metadata of the service provider is valid. For example, `48h`."},
+	{attr: "allow_sign_up", key: "allowSignUp", valueType: schema.TypeBool, description: "Whether to create Grafana users for new users of the provider."},
+	{attr: "auto_login", key: "autoLogin", valueType: schema.TypeBool, description: "Whether to log in with the provider without showing the login page."},
+	{attr: "allow_idp_initiated", key: "allowIdpInitiated", valueType: schema.TypeBool, description: "Whether to allow logins initiated by the identity provider."},
+	{attr: "relay_state", key: "relayState", valueType: schema.TypeString, description: "The relay state of logins initiated by the identity provider."},
+	{attr: "single_logout", key: "singleLogout", valueType: schema.TypeBool, description: "Whether to enable single logout."},
+	{attr: "name_id_format", key: "nameIdFormat", valueType: schema.TypeString, description: "The format of the name ID requested from the identity provider."},
+	{attr: "assertion_attribute_name", key: "assertionAttributeName", valueType: schema.TypeString, description: "The assertion attribute holding the name of the user."},
+	{attr: "assertion_attribute_login", key: "assertionAttributeLogin", valueType: schema.TypeString, description: "The assertion attribute holding the login of the user."},
+	{attr: "assertion_attribute_email", key: "assertionAttributeEmail", valueType: schema.TypeString, description: "The assertion attribute holding the email of the user."},
+	{attr: "assertion_attribute_groups", key: "assertionAttributeGroups", valueType: schema.TypeString, description: "The assertion attribute holding the groups of the user."},
+	{attr: "assertion_attribute_role", key: "assertionAttributeRole", valueType: schema.TypeString, description: "The assertion attribute holding the role of the user."},
+	{attr: "assertion_attribute_org", key: "assertionAttributeOrg", valueType: schema.TypeString, description: "The assertion attribute holding the organizations of the user."},
+	{attr: "allowed_organizations", key: "allowedOrganizations", valueType: schema.TypeString, description: "Comma-separated organizations whose members are allowed to log in."},
+	{attr: "org_mapping", key: "orgMapping", valueType: schema.TypeString, description: "Comma-separated mappings of the provider's organizations to Grafana organizations and roles. For example, `Engineering:2:Editor`."},
+	{attr: "role_values_none", key: "roleValuesNone", valueType: schema.TypeString, description: "Comma-separated role values that map to the `None` role."},
+	{attr: "role_values_viewer", key: "roleValuesViewer", valueType: schema.TypeString, description: "Comma-separated role values that map to the `Viewer` role."},
+	{attr: "role_values_editor", key: "roleValuesEditor", valueType: schema.TypeString, description: "Comma-separated role values that map to the `Editor` role."},
+	{attr: "role_values_admin", key: "roleValuesAdmin", valueType: schema.TypeString, description: "Comma-separated role values that map to the `Admin` role."},
+	{attr: "role_values_grafana_admin", key: "roleValuesGrafanaAdmin", valueType: schema.TypeString, description: "Comma-separated role values that make users Grafana server admins."},
+	{attr: "skip_org_role_sync", key: "skipOrgRoleSync", valueType: schema.TypeBool, description: "Whether to stop synchronizing the organization roles of users from the provider."},
+}
+
+var ssoLDAPSettingsFields = []ssoSettingsField{
+	{attr: "enabled", key: "enabled", valueType: schema.TypeBool, description: "Whether LDAP authentication is enabled."},
+	{attr: "allow_sign_up", key: "allowSignUp", valueType: schema.TypeBool, description: "Whether to create Grafana users for new LDAP users."},
+	{attr: "skip_org_role_sync", key: "skipOrgRoleSync", valueType: schema.TypeBool, description: "Whether to stop synchronizing the organization roles of users from LDAP."},
+}
+
+// ssoLDAPServerFields are the fields of the LDAP servers. The LDAP configuration uses snake case keys.
+var ssoLDAPServerFields = []ssoSettingsField{
+	{attr: "host", key: "host", valueType: schema.TypeString, description: "The host of the LDAP server.", required: true},
+	{attr: "port", key: "port", valueType: schema.TypeInt, description: "The port of the LDAP server.", validate: validation.IsPortNumber},
+	{attr: "use_ssl", key: "use_ssl", valueType: schema.TypeBool, description: "Whether to use TLS to connect to the server."},
+	{attr: "start_tls", key: "start_tls", valueType: schema.TypeBool, description: "Whether to use STARTTLS to connect to the server."},
+	{attr: "ssl_skip_verify", key: "ssl_skip_verify", valueType: schema.TypeBool, description: "Whether to skip the verification of the server's TLS certificate."},
+	{attr: "root_ca_cert", key: "root_ca_cert", valueType: schema.TypeString, description: "The path of the CA certificate of the server."},
+	{attr: "client_cert", key: "client_cert", valueType: schema.TypeString, description: "The path of the TLS client certificate."},
+	{attr: "client_key", key: "client_key", valueType: schema.TypeString, description: "The path of the TLS client key."},
+	{attr: "timeout", key: "timeout", valueType: schema.TypeInt, description: "The timeout of connections to the server, in seconds.", validate: validation.IntAtLeast(1)},
+	{attr: "bind_dn", key: "bind_dn", valueType: schema.TypeString, description: "The DN used to bind to the server."},
+	{attr: "bind_password", key: "bind_password", valueType: schema.TypeString, description: "The password used to bind to the server.", secret: true},
+	{attr: "search_filter", key: "search_filter", valueType: schema.TypeString, description: "The filter searching users. For example, `(cn=%s)`."},
+	{attr: "group_search_filter", key: "group_search_filter", valueType: schema.TypeString, description: "The filter searching the groups of users, for servers that don't support `memberOf`."},
+	{attr: "group_search_filter_user_attribute", key: "group_search_filter_user_attribute", valueType: schema.TypeString, description: "The user attribute used in the group search filter."},
+}
+
+func ResourceSSOSettings() *schema.Resource {
+	var providers []string
+	for provider := range ssoSettingsBlocks {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	blocks := []string{ssoOAuth2SettingsBlock, ssoSAMLSettingsBlock, ssoLDAPSettingsBlock}
+
+	return &schema.Resource{
+
+		Description: `
+Manages the settings of an SSO provider. Settings that aren't set keep the values of the Grafana configuration file.
+Deleting the resource resets the provider to these values.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/setup-grafana/configure-security/configure-authentication/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/sso-settings/)
+
+This resource represents an instance-scoped resource and uses Grafana's admin APIs.
+It does not work with API tokens or service accounts which are org-scoped.
+You must use basic auth.
+`,
+
+		CreateContext: UpdateSSOSettings,
+		ReadContext:   ReadSSOSettings,
+		UpdateContext: UpdateSSOSettings,
+		DeleteContext: DeleteSSOSettings,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: validateSSOSettings,
+
+		Schema: map[string]*schema.Schema{
+			"provider_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(providers, false),
+				Description:  fmt.Sprintf("The name of the SSO provider. Options: `%s`.", strings.Join(providers, "`, `")),
+			},
+			ssoOAuth2SettingsBlock: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Description:  "The settings of the OAuth2 providers: `azuread`, `generic_oauth`, `github`, `gitlab`, `google` and `okta`.",
+				Elem:         &schema.Resource{Schema: ssoSettingsFieldSchemas(ssoOAuth2SettingsFields)},
+			},
+			ssoSAMLSettingsBlock: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Description:  "The settings of the `saml` provider. This is only available in Grafana Enterprise.",
+				Elem:         &schema.Resource{Schema: ssoSettingsFieldSchemas(ssoSAMLSettingsFields)},
+			},
+			ssoLDAPSettingsBlock: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Description:  "The settings of the `ldap` provider.",
+				Elem:         &schema.Resource{Schema: ssoLDAPSettingsSchema()},
+			},
+		},
+	}
+}
+
+func ssoSettingsFieldSchemas(fields []ssoSettingsField) map[string]*schema.Schema {
+	schemas := map[string]*schema.Schema{}
+	for _, field := range fields {
+		schemas[field.attr] = &schema.Schema{
+			Type:     field.valueType,
+			Required: field.required,
+			Optional: !field.required,
+			// Unset booleans keep the value of the configuration file, which is read back
+			Computed:     field.valueType == schema.TypeBool,
+			Sensitive:    field.secret,
+			ValidateFunc: field.validate,
+			Description:  field.description,
+		}
+	}
+	return schemas
+}
+
+func ssoLDAPSettingsSchema() map[string]*schema.Schema {
+	serverSchema := ssoSettingsFieldSchemas(ssoLDAPServerFields)
+	serverSchema["search_base_dns"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		Description: "The base DNs searched for users.",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	serverSchema["group_search_base_dns"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "The base DNs searched for groups.",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	serverSchema["attributes"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Description: "The LDAP attributes holding the information of users. Keys: `name`, `surname`, `username`, `member_of` and `email`.",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	serverSchema["group_mappings"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "The mappings of LDAP groups to Grafana organization roles.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"group_dn": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The DN of the LDAP group. `*` matches all users.",
+				},
+				"org_role": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"Admin", "Editor", "Viewer", "None"}, false),
+					Description:  "The role of the members of the group in the organization. Options: `Admin`, `Editor`, `Viewer` or `None`.",
+				},
+				"org_id": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     1,
+					Description: "The ID of the organization.",
+				},
+				"grafana_admin": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether the members of the group are Grafana server admins.",
+				},
+			},
+		},
+	}
+
+	settingsSchema := ssoSettingsFieldSchemas(ssoLDAPSettingsFields)
+	settingsSchema["config"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: "The LDAP configuration.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"servers": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Description: "The LDAP servers.",
+					Elem:        &schema.Resource{Schema: serverSchema},
+				},
+			},
+		},
+	}
+	return settingsSchema
+}
+
+// validateSSOSettings checks that the settings block matches the provider, and that providers without default endpoints set them.
+func validateSSOSettings(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("provider_name") {
+		return nil
+	}
+	provider := d.Get("provider_name").(string)
+	block := ssoSettingsBlocks[provider]
+	if list, ok := d.Get(block).([]interface{}); !ok || len(list) == 0 {
+		return fmt.Errorf("the %s provider must be configured with the %s block", provider, block)
+	}
+
+	if block != ssoOAuth2SettingsBlock {
+		return nil
+	}
+	for _, p := range ssoCustomURLProviders {
+		if p != provider {
+			continue
+		}
+		for _, attr := range []string{"auth_url", "token_url"} {
+			key := fmt.Sprintf("%s.0.%s", block, attr)
+			if d.NewValueKnown(key) && d.Get(key).(string) == "" {
+				return fmt.Errorf("%s must be set for the %s provider", attr, provider)
+			}
+		}
+	}
+	return nil
+}
+
+func UpdateSSOSettings(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaOAPI
+	provider := d.Get("provider_name").(string)
+
+	block := ssoSettingsBlocks[provider]
+	values := d.Get(block).([]interface{})[0].(map[string]interface{})
+	rawBlock := rawListElement(d.GetRawConfig(), block, 0)
+	isSet := func(attr string) bool { return rawAttributeSet(rawBlock, attr) }
+
+	var settings map[string]interface{}
+	switch block {
+	case ssoOAuth2SettingsBlock:
+		settings = ssoSettingsFromValues(ssoOAuth2SettingsFields, values, isSet)
+	case ssoSAMLSettingsBlock:
+		settings = ssoSettingsFromValues(ssoSAMLSettingsFields, values, isSet)
+	case ssoLDAPSettingsBlock:
+		settings = ssoLDAPSettingsFromValues(values, isSet)
+	}
+
+	body := map[string]interface{}{"settings": settings}
+	if err := oapiRequest(client, "PUT", "/v1/sso-settings/"+provider, nil, body, nil); err != nil {
+		return diag.Errorf("failed to update the settings of the %s SSO provider: %s", provider, err)
+	}
+
+	d.SetId(provider)
+	return ReadSSOSettings(ctx, d, meta)
+}
+
+func ReadSSOSettings(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaOAPI
+	provider := d.Id()
+	block, ok := ssoSettingsBlocks[provider]
+	if !ok {
+		return diag.Errorf("invalid SSO provider %q", provider)
+	}
+
+	settings, err := getSSOSettings(client, provider)
+	if err, shouldReturn := common.CheckReadError("SSO settings", d, err); shouldReturn {
+		return err
+	}
+	// Settings that only come from the configuration file aren't managed by Terraform
+	if settings.Source != "database" && !d.IsNewResource() {
+		log.Printf("[WARN] removing SSO settings %s from state because they have been reset", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	var current map[string]interface{}
+	if list, ok := d.Get(block).([]interface{}); ok && len(list) > 0 && list[0] != nil {
+		current = list[0].(map[string]interface{})
+	}
+
+	var values map[string]interface{}
+	switch block {
+	case ssoOAuth2SettingsBlock:
+		values = ssoSettingsToValues(ssoOAuth2SettingsFields, settings.Settings, current)
+	case ssoSAMLSettingsBlock:
+		values = ssoSettingsToValues(ssoSAMLSettingsFields, settings.Settings, current)
+	case ssoLDAPSettingsBlock:
+		values = ssoLDAPSettingsToValues(settings.Settings, current)
+	}
+
+	d.Set("provider_name", provider)
+	if err := d.Set(block, []interface{}{values}); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func DeleteSSOSettings(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*common.Client).GrafanaOAPI
+	// Deleting the settings resets the provider to the settings of the configuration file
+	err := oapiRequest(client, "DELETE", "/v1/sso-settings/"+d.Id(), nil, nil, nil)
+	diags, _ := common.CheckReadError("SSO settings", d, err)
+	return diags
+}
+
+type ssoSettings struct {
+	Provider string                 `json:"provider"`
+	Source   string                 `json:"source"`
+	Settings map[string]interface{} `json:"settings"`
+}
+
+func getSSOSettings(client *goapi.GrafanaHTTPAPI, provider string) (*ssoSettings, error) {
+	var settings ssoSettings
+	if err := oapiRequest(client, "GET", "/v1/sso-settings/"+provider, nil, nil, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// ssoSettingsFromValues maps the values of a settings block to the keys of the API.
+// Unset values are left out, so that they keep the values of the configuration file.
+func ssoSettingsFromValues(fields []ssoSettingsField, values map[string]interface{}, isSet func(attr string) bool) map[string]interface{} {
+	settings := map[string]interface{}{}
+	for _, field := range fields {
+		if !isSet(field.attr) {
+			continue
+		}
+		value := values[field.attr]
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				continue
+			}
+		case int:
+			if v == 0 {
+				continue
+			}
+		}
+		settings[field.key] = value
+	}
+	return settings
+}
+
+// ssoSettingsToValues maps the settings returned by the API back to the values of a settings block.
+// Only the attributes in the state are read, unless the resource is being imported: Grafana returns all settings, including defaults.
+// Secrets are redacted, so their values are kept, unless they have been removed from Grafana.
+func ssoSettingsToValues(fields []ssoSettingsField, settings, current map[string]interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, field := range fields {
+		if field.secret {
+			if redacted, _ := settings[field.key].(string); redacted != "" {
+				values[field.attr] = current[field.attr]
+			}
+			continue
+		}
+		if current != nil && isZeroSSOValue(current[field.attr]) {
+			continue
+		}
+		value, ok := settings[field.key]
+		if !ok || value == nil {
+			continue
+		}
+		switch field.valueType {
+		case schema.TypeString:
+			// Lists can be returned as arrays or as comma-separated strings
+			if list, ok := value.([]interface{}); ok {
+				var items []string
+				for _, item := range list {
+					items = append(items, fmt.Sprint(item))
+				}
+				value = strings.Join(items, ",")
+			} else if _, ok := value.(string); !ok {
+				value = fmt.Sprint(value)
+			}
+		case schema.TypeInt:
+			if f, ok := value.(float64); ok {
+				value = int(f)
+			}
+		case schema.TypeBool:
+			if s, ok := value.(string); ok {
+				value = s == "true"
+			}
+		}
+		values[field.attr] = value
+	}
+	return values
+}
+
+func isZeroSSOValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	}
+	// Booleans are always read, false is a meaningful value
+	return false
+}
+
+func ssoLDAPSettingsFromValues(values map[string]interface{}, isSet func(attr string) bool) map[string]interface{} {
+	settings := ssoSettingsFromValues(ssoLDAPSettingsFields, values, isSet)
+
+	var servers []interface{}
+	config := values["config"].([]interface{})[0].(map[string]interface{})
+	for _, s := range config["servers"].([]interface{}) {
+		serverValues := s.(map[string]interface{})
+		// The servers replace the ones of the configuration file, so all of their fields are sent
+		server := ssoSettingsFromValues(ssoLDAPServerFields, serverValues, func(string) bool { return true })
+		server["search_base_dns"] = serverValues["search_base_dns"]
+		if groupSearchBaseDNs := serverValues["group_search_base_dns"].([]interface{}); len(groupSearchBaseDNs) > 0 {
+			server["group_search_base_dns"] = groupSearchBaseDNs
+		}
+		if attributes := serverValues["attributes"].(map[string]interface{}); len(attributes) > 0 {
+			server["attributes"] = attributes
+		}
+		var mappings []interface{}
+		for _, m := range serverValues["group_mappings"].([]interface{}) {
+			mapping := m.(map[string]interface{})
+			mappings = append(mappings, map[string]interface{}{
+				"group_dn":      mapping["group_dn"],
+				"org_role":      mapping["org_role"],
+				"org_id":        mapping["org_id"],
+				"grafana_admin": mapping["grafana_admin"],
+			})
+		}
+		if len(mappings) > 0 {
+			server["group_mappings"] = mappings
+		}
+		servers = append(servers, server)
+	}
+	settings["config"] = map[string]interface{}{"servers": servers}
+	return settings
+}
+
+func ssoLDAPSettingsToValues(settings, current map[string]interface{}) map[string]interface{} {
+	values := ssoSettingsToValues(ssoLDAPSettingsFields, settings, current)
+
+	var currentServers []interface{}
+	if current != nil {
+		if config, ok := current["config"].([]interface{}); ok && len(config) > 0 && config[0] != nil {
+			currentServers, _ = config[0].(map[string]interface{})["servers"].([]interface{})
+		}
+	}
+
+	config, _ := settings["config"].(map[string]interface{})
+	apiServers, _ := config["servers"].([]interface{})
+	var servers []interface{}
+	for i, s := range apiServers {
+		apiServer, _ := s.(map[string]interface{})
+		// The LDAP configuration is always read in full, only the secrets of the current state are kept
+		var currentServer map[string]interface{}
+		if i < len(currentServers) {
+			currentServer, _ = currentServers[i].(map[string]interface{})
+		}
+		server := ssoSettingsToValues(ssoLDAPServerFields, apiServer, nil)
+		for _, field := range ssoLDAPServerFields {
+			if redacted, _ := apiServer[field.key].(string); field.secret && redacted != "" {
+				server[field.attr] = currentServer[field.attr]
+			}
+		}
+		server["search_base_dns"] = apiServer["search_base_dns"]
+		server["group_search_base_dns"] = apiServer["group_search_base_dns"]
+		server["attributes"] = apiServer["attributes"]
+
+		var mappings []interface{}
+		apiMappings, _ := apiServer["group_mappings"].([]interface{})
+		for _, m := range apiMappings {
+			mapping, _ := m.(map[string]interface{})
+			orgID := 1
+			if f, ok := mapping["org_id"].(float64); ok {
+				orgID = int(f)
+			}
+			grafanaAdmin, _ := mapping["grafana_admin"].(bool)
+			mappings = append(mappings, map[string]interface{}{
+				"group_dn":      mapping["group_dn"],
+				"org_role":      mapping["org_role"],
+				"org_id":        orgID,
+				"grafana_admin": grafanaAdmin,
+			})
+		}
+		server["group_mappings"] = mappings
+		servers = append(servers, server)
+	}
+	values["config"] = []interface{}{map[string]interface{}{"servers": servers}}
+	return values
+}
@@ -0,0 +1,97 @@
+package grafana_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSSOSettings_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=11.3.0")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_sso_settings/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_sso_settings.github", "id", "github"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.github", "oauth2_settings.0.client_id", "github-client-id"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.github", "oauth2_settings.0.client_secret", "github-client-secret"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.github", "oauth2_settings.0.allowed_organizations", "my-org"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.github", "oauth2_settings.0.enabled", "true"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.ldap", "id", "ldap"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.ldap", "ldap_settings.0.config.0.servers.0.host", "ldap.example.com"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.ldap", "ldap_settings.0.config.0.servers.0.bind_password", "ldap-password"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.ldap", "ldap_settings.0.config.0.servers.0.group_mappings.#", "2"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.ldap", "ldap_settings.0.config.0.servers.0.group_mappings.0.org_id", "1"),
+				),
+			},
+			{
+				ResourceName:            "grafana_sso_settings.github",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"oauth2_settings"},
+			},
+		},
+	})
+}
+
+func TestAccSSOSettings_update(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=11.0.0")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSSOSettingsGenericOAuth("Generic", "https://example.com/token"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_sso_settings.generic", "oauth2_settings.0.name", "Generic"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.generic", "oauth2_settings.0.token_url", "https://example.com/token"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.generic", "oauth2_settings.0.use_pkce", "true"),
+				),
+			},
+			{
+				Config: testAccSSOSettingsGenericOAuth("Updated", "https://example.com/new-token"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_sso_settings.generic", "oauth2_settings.0.name", "Updated"),
+					resource.TestCheckResourceAttr("grafana_sso_settings.generic", "oauth2_settings.0.token_url", "https://example.com/new-token"),
+				),
+			},
+			{
+				// Providers without default endpoints must set them
+				Config:      testAccSSOSettingsGenericOAuth("Updated", ""),
+				ExpectError: regexp.MustCompile("token_url must be set for the generic_oauth provider"),
+			},
+			{
+				Config: `
+resource "grafana_sso_settings" "generic" {
+  provider_name = "generic_oauth"
+  saml_settings {
+    enabled = true
+  }
+}`,
+				ExpectError: regexp.MustCompile("the generic_oauth provider must be configured with the oauth2_settings block"),
+			},
+		},
+	})
+}
+
+func testAccSSOSettingsGenericOAuth(name, tokenURL string) string {
+	return fmt.Sprintf(`
+resource "grafana_sso_settings" "generic" {
+  provider_name = "generic_oauth"
+  oauth2_settings {
+    name          = %[1]q
+    client_id     = "client-id"
+    client_secret = "client-secret"
+    auth_url      = "https://example.com/authorize"
+    token_url     = %[2]q
+    api_url       = "https://example.com/userinfo"
+    use_pkce      = true
+  }
+}`, name, tokenURL)
+}
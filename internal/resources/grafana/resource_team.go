@@ -91,6 +91,15 @@ to the team. Note: users specified here must already exist in Grafana.
 				Description: `
 Ignores team members that have been added to team by [Team Sync](https://grafana.com/docs/grafana/latest/setup-grafana/configure-security/configure-team-sync/).
 Team Sync can be provisioned using [grafana_team_external_group resource](https://registry.terraform.io/providers/grafana/grafana/latest/docs/resources/team_external_group).
+`,
+			},
+			"ignore_externally_managed_members": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: `
+Only manage the members listed in ` + "`members`" + `. Other members of the team, for example the ones managed
+by [grafana_team_member resources](https://registry.terraform.io/providers/grafana/grafana/latest/docs/resources/team_member), are left untouched.
 `,
 			},
 			"preferences": {
@@ -294,6 +303,9 @@ func readTeamMembers(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) diag.
 		return diag.FromErr(err)
 	}
 	teamMembers := resp.GetPayload()
+	// The data source doesn't have this attribute
+	ignoreExternallyManaged, hasManagedKey := d.GetOk("ignore_externally_managed_members")
+	managedMembers := d.Get("members").(*schema.Set)
 	memberSlice := []string{}
 	for _, teamMember := range teamMembers {
 		// Admin is added automatically to the team when the team is created.
@@ -307,6 +319,10 @@ func readTeamMembers(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) diag.
 		if (!hasKey || ignoreExternallySynced.(bool)) && len(teamMember.Labels) > 0 {
 			continue
 		}
+		// Members that aren't listed are managed elsewhere
+		if hasManagedKey && ignoreExternallyManaged.(bool) && !managedMembers.Contains(teamMember.Email) {
+			continue
+		}
 		memberSlice = append(memberSlice, teamMember.Email)
 	}
 	d.Set("members", memberSlice)
@@ -0,0 +1,230 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/org"
+	"github.com/grafana/grafana-openapi-client-go/client/teams"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// teamAdminPermission is the permission of team members that are admins of the team.
+const teamAdminPermission = models.PermissionType(4)
+
+func ResourceTeamMember() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages the membership of a single user in a team.
+Unlike the ` + "`members`" + ` attribute of ` + "`grafana_team`" + `, it doesn't remove the other members of the team.
+To use both, set ` + "`ignore_externally_managed_members`" + ` on the team.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/team-management/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/team/#add-team-member)
+`,
+
+		CreateContext: CreateTeamMember,
+		ReadContext:   ReadTeamMember,
+		UpdateContext: UpdateTeamMember,
+		DeleteContext: DeleteTeamMember,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the team.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					_, old = SplitOrgResourceID(old)
+					_, new = SplitOrgResourceID(new)
+					return old == new
+				},
+			},
+			"email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"email", "login"},
+				Description:  "The email of the user. The user must already exist in the organization of the team.",
+			},
+			"login": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"email", "login"},
+				Description:  "The login of the user. The user must already exist in the organization of the team.",
+			},
+			"admin": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the user is an admin of the team.",
+			},
+			"user_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the user.",
+			},
+		},
+	}
+}
+
+func CreateTeamMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	orgID, teamIDStr := SplitOrgResourceID(d.Get("team_id").(string))
+	client, orgID, _ := OAPIClientFromExistingOrgResource(meta, MakeOrgResourceID(orgID, teamIDStr))
+
+	userID, err := findOrgUserID(client, d.Get("email").(string), d.Get("login").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Users that are already members of the team are adopted, like in grafana_org_user
+	resp, err := client.Teams.GetTeamMembers(teams.NewGetTeamMembersParams().WithTeamID(teamIDStr), nil)
+	if err != nil {
+		return diag.Errorf("failed to get the members of team %s: %s", teamIDStr, err)
+	}
+	alreadyMember, admin := false, false
+	for _, m := range resp.GetPayload() {
+		if m.UserID == userID {
+			alreadyMember, admin = true, m.Permission == teamAdminPermission
+			break
+		}
+	}
+	if !alreadyMember {
+		params := teams.NewAddTeamMemberParams().WithTeamID(teamIDStr).WithBody(&models.AddTeamMemberCommand{
+			UserID: userID,
+		})
+		if _, err := client.Teams.AddTeamMember(params, nil); err != nil {
+			return diag.Errorf("failed to add user %d to team %s: %s", userID, teamIDStr, err)
+		}
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, fmt.Sprintf("%s:%d", teamIDStr, userID)))
+	if d.Get("admin").(bool) != admin {
+		if err := updateTeamMemberPermission(client, teamIDStr, userID, true); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadTeamMember(ctx, d, meta)
+}
+
+func ReadTeamMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID, teamIDStr, userID, err := teamMemberClientFromID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.Teams.GetTeamMembers(teams.NewGetTeamMembersParams().WithTeamID(teamIDStr), nil)
+	if err, shouldReturn := common.CheckReadError("team member", d, err); shouldReturn {
+		return err
+	}
+	var member *models.TeamMemberDTO
+	for _, m := range resp.GetPayload() {
+		if m.UserID == userID {
+			member = m
+			break
+		}
+	}
+	if member == nil {
+		log.Printf("[WARN] removing team member %s from state because it no longer exists in grafana", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, fmt.Sprintf("%s:%d", teamIDStr, userID)))
+	d.Set("team_id", MakeOrgResourceID(orgID, teamIDStr))
+	d.Set("user_id", userID)
+	d.Set("email", member.Email)
+	d.Set("login", member.Login)
+	d.Set("admin", member.Permission == teamAdminPermission)
+
+	return nil
+}
+
+func UpdateTeamMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, teamIDStr, userID, err := teamMemberClientFromID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateTeamMemberPermission(client, teamIDStr, userID, d.Get("admin").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+	return ReadTeamMember(ctx, d, meta)
+}
+
+func DeleteTeamMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, teamIDStr, userID, err := teamMemberClientFromID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Teams.RemoveTeamMember(teams.NewRemoveTeamMemberParams().WithTeamID(teamIDStr).WithUserID(userID), nil)
+	if err != nil && !common.IsOAPINotFoundError(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func updateTeamMemberPermission(client *goapi.GrafanaHTTPAPI, teamID string, userID int64, admin bool) error {
+	var permission models.PermissionType
+	if admin {
+		permission = teamAdminPermission
+	}
+	params := teams.NewUpdateTeamMemberParams().WithTeamID(teamID).WithUserID(userID).WithBody(&models.UpdateTeamMemberCommand{
+		Permission: permission,
+	})
+	_, err := client.Teams.UpdateTeamMember(params, nil)
+	return err
+}
+
+// teamMemberClientFromID splits the ID of a team member: `<org_id>:<team_id>:<user_id>`.
+// The org ID can be omitted when importing, to use the default org.
+func teamMemberClientFromID(meta interface{}, id string) (*goapi.GrafanaHTTPAPI, int64, string, int64, error) {
+	parts := strings.Split(id, ":")
+	var client *goapi.GrafanaHTTPAPI
+	var orgID int64
+	switch len(parts) {
+	case 2:
+		client, orgID, _ = OAPIClientFromExistingOrgResource(meta, "")
+	case 3:
+		client, orgID, _ = OAPIClientFromExistingOrgResource(meta, id)
+		parts = parts[1:]
+	default:
+		return nil, 0, "", 0, fmt.Errorf("invalid team member ID %q, expected [<org_id>:]<team_id>:<user_id>", id)
+	}
+	userID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, 0, "", 0, fmt.Errorf("invalid user ID in team member ID %q: %s", id, err)
+	}
+	return client, orgID, parts[0], userID, nil
+}
+
+// findOrgUserID returns the ID of the user of the client's organization with the given email or login.
+func findOrgUserID(client *goapi.GrafanaHTTPAPI, email, login string) (int64, error) {
+	resp, err := client.Org.GetOrgUsersForCurrentOrg(org.NewGetOrgUsersForCurrentOrgParams(), nil)
+	if err != nil {
+		return 0, err
+	}
+	for _, u := range resp.GetPayload() {
+		if (email != "" && u.Email == email) || (login != "" && u.Login == login) {
+			return u.UserID, nil
+		}
+	}
+	if email != "" {
+		return 0, fmt.Errorf("user with email %s does not exist in the organization", email)
+	}
+	return 0, fmt.Errorf("user with login %s does not exist in the organization", login)
+}
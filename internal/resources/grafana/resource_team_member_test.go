@@ -0,0 +1,63 @@
+package grafana_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTeamMember_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var team models.TeamDTO
+	config := testutils.TestAccExample(t, "resources/grafana_team_member/resource.tf")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		CheckDestroy:      teamCheckExists.destroyed(&team, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					teamCheckExists.exists("grafana_team.team", &team),
+					// The member managed by grafana_team_member is ignored by the team
+					resource.TestCheckResourceAttr("grafana_team.team", "members.#", "1"),
+					resource.TestCheckResourceAttr("grafana_team.team", "members.0", "alice@example.com"),
+					resource.TestCheckResourceAttrPair("grafana_team_member.bob", "team_id", "grafana_team.team", "id"),
+					resource.TestCheckResourceAttrPair("grafana_team_member.bob", "user_id", "grafana_user.bob", "user_id"),
+					resource.TestCheckResourceAttr("grafana_team_member.bob", "login", "bob"),
+					resource.TestCheckResourceAttr("grafana_team_member.bob", "email", "bob@example.com"),
+					resource.TestCheckResourceAttr("grafana_team_member.bob", "admin", "true"),
+				),
+			},
+			{
+				Config: strings.Replace(config, "admin   = true", "admin   = false", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_team_member.bob", "admin", "false"),
+					resource.TestCheckResourceAttr("grafana_team.team", "members.#", "1"),
+				),
+			},
+			{
+				// Users that are already members of the team are adopted
+				Config: strings.Replace(config, "admin   = true", "admin   = false", 1) + `
+resource "grafana_team_member" "alice" {
+  team_id = grafana_team.team.id
+  email   = grafana_user.alice.email
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("grafana_team_member.alice", "user_id", "grafana_user.alice", "user_id"),
+					resource.TestCheckResourceAttr("grafana_team_member.alice", "admin", "false"),
+					resource.TestCheckResourceAttr("grafana_team.team", "members.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "grafana_team_member.bob",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
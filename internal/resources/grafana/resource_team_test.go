@@ -48,7 +48,7 @@ func TestAccTeam_basic(t *testing.T) {
 				ResourceName:            "grafana_team.test",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"ignore_externally_synced_members"},
+				ImportStateVerifyIgnore: []string{"ignore_externally_synced_members", "ignore_externally_managed_members"},
 			},
 		},
 	})
@@ -95,7 +95,7 @@ func TestAccTeam_preferences(t *testing.T) {
 				ResourceName:            "grafana_team.test",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"ignore_externally_synced_members"},
+				ImportStateVerifyIgnore: []string{"ignore_externally_synced_members", "ignore_externally_managed_members"},
 			},
 		},
 	})
@@ -215,7 +215,7 @@ func TestAccTeam_Members(t *testing.T) {
 				ResourceName:            "grafana_team.test",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"ignore_externally_synced_members"},
+				ImportStateVerifyIgnore: []string{"ignore_externally_synced_members", "ignore_externally_managed_members"},
 			},
 			{
 				Config: testAccTeamDefinition(teamName, nil, false, nil),